@@ -0,0 +1,59 @@
+// Copyright 2024 Matthew P. Dargan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/csv"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/matthewdargan/astro/ephem"
+)
+
+// julianOffset is the Julian date of the epoch used by [ephem.Julian].
+const julianOffset = 2415020
+
+var csvHeader = []string{"time", "body", "jd", "ra_deg", "dec_deg", "az", "el", "semi", "mag", "dist"}
+
+// writeTable writes a CSV ephemeris of bodies for n times starting at t and
+// separated by step.
+func writeTable(w io.Writer, obs *ephem.Observer, bodies []ephem.Body, t time.Time, step time.Duration, n int) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	f := func(v float64) string {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	for i := range n {
+		d := t.Add(time.Duration(i) * step)
+		for _, b := range bodies {
+			c, err := obs.Position(b.Name, d)
+			if err != nil {
+				return err
+			}
+			ra := math.Mod(c.RA+2*math.Pi, 2*math.Pi)
+			row := []string{
+				clock(d).Format(time.RFC3339),
+				b.Name,
+				f(ephem.Julian(d) + julianOffset),
+				f(ra / radian),
+				f(c.Decl / radian),
+				f(c.Az),
+				f(c.El),
+				f(c.Semi),
+				f(c.Mag),
+				f(c.Dist),
+			}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
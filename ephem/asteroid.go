@@ -0,0 +1,130 @@
+// Copyright 2024 Matthew P. Dargan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ephem
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// An Asteroid holds the osculating orbital elements of a minor planet.
+// Angles are referred to the ecliptic and equinox of J2000.
+type Asteroid struct {
+	Designation string    // number or provisional designation, such as "1" or "2024 AB"
+	Name        string    // such as "Ceres"
+	Epoch       time.Time // epoch of the elements
+	A           float64   // semimajor axis (au)
+	E           float64   // eccentricity
+	Incl        float64   // inclination (degrees)
+	Node        float64   // longitude of the ascending node (degrees)
+	Peri        float64   // argument of perihelion (degrees)
+	M           float64   // mean anomaly at the epoch (degrees)
+	H, G        float64   // absolute magnitude and slope parameter
+}
+
+// ReadAsteroids reads asteroid elements in the format of the MPCORB.DAT file
+// of the Minor Planet Center. If the file has a header, it ends with a line
+// of dashes. Blank lines are skipped.
+func ReadAsteroids(r io.Reader) ([]Asteroid, error) {
+	var (
+		as  []Asteroid
+		err error
+	)
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		l := s.Text()
+		if strings.HasPrefix(l, "-----") {
+			// Whatever came before was the header.
+			as = nil
+			err = nil
+			continue
+		}
+		if strings.TrimSpace(l) == "" || err != nil {
+			continue
+		}
+		a, e := parseAsteroid(l)
+		if e != nil {
+			err = fmt.Errorf("line %d: %v", n, e)
+			continue
+		}
+		as = append(as, a)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return as, s.Err()
+}
+
+func parseAsteroid(l string) (Asteroid, error) {
+	if len(l) < 103 {
+		return Asteroid{}, errors.New("short asteroid record")
+	}
+	field := func(i, j int) string {
+		return strings.TrimSpace(l[i:j])
+	}
+	var a Asteroid
+	var err error
+	if a.Epoch, err = unpackEpoch(field(20, 25)); err != nil {
+		return Asteroid{}, err
+	}
+	for _, f := range []struct {
+		v    *float64
+		i, j int
+	}{
+		{&a.M, 26, 35},
+		{&a.Peri, 37, 46},
+		{&a.Node, 48, 57},
+		{&a.Incl, 59, 68},
+		{&a.E, 70, 79},
+		{&a.A, 92, 103},
+	} {
+		if *f.v, err = strconv.ParseFloat(field(f.i, f.j), 64); err != nil {
+			return Asteroid{}, err
+		}
+	}
+	// The magnitude parameters may be missing; 0.15 is the usual slope.
+	a.H, _ = strconv.ParseFloat(field(8, 13), 64)
+	if a.G, err = strconv.ParseFloat(field(14, 19), 64); err != nil {
+		a.G = 0.15
+	}
+	a.Designation = field(0, 7)
+	if len(l) > 166 {
+		// The readable designation, such as "(1) Ceres" or "2024 AB".
+		d := field(166, min(len(l), 194))
+		a.Designation, a.Name = d, d
+		if strings.HasPrefix(d, "(") {
+			if i := strings.Index(d, ")"); i > 0 {
+				a.Designation = d[1:i]
+				a.Name = strings.TrimSpace(d[i+1:])
+			}
+		}
+	}
+	if a.Name == "" {
+		a.Name = a.Designation
+	}
+	return a, nil
+}
+
+// unpackEpoch returns the date in the packed form used by the Minor Planet
+// Center, such as K24AH for 2024 October 17.
+func unpackEpoch(s string) (time.Time, error) {
+	const digits = "0123456789ABCDEFGHIJKLMNOPQRSTUV"
+	if len(s) != 5 {
+		return time.Time{}, fmt.Errorf("bad epoch %q", s)
+	}
+	c := strings.IndexByte(digits, s[0])
+	y, err := strconv.Atoi(s[1:3])
+	m := strings.IndexByte(digits, s[3])
+	d := strings.IndexByte(digits, s[4])
+	if c < 18 || err != nil || m < 1 || m > 12 || d < 1 {
+		return time.Time{}, fmt.Errorf("bad epoch %q", s)
+	}
+	return time.Date(c*100+y, time.Month(m), d, 0, 0, 0, 0, time.UTC), nil
+}
@@ -0,0 +1,860 @@
+// Copyright 2024 Matthew P. Dargan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ephem
+
+import "math"
+
+func (c *state) fSun() {
+	c.beta = 0
+	c.rad = 0
+	c.lambda = 0
+	c.motion = 0
+	c.helio()
+	c.geo()
+	c.seday = c.eday
+	c.salph = c.alpha
+	c.sdelt = c.delta
+	c.mag = -26.74
+}
+
+func (c *state) sun() {
+	argp := (281.220833 + 0.0000470684*c.eday + 0.000453*c.capt2 + 0.000003*c.capt3) * radian
+	anom := 358.475845 + 0.9856002670*c.eday - 0.00015*c.capt2 - 0.000003*c.capt3
+	c.motion = 0.9856473354
+	dmoon := 350.737681 + 12.1907491914*c.eday - 0.001436*c.capt2
+	gmoon := 11.250889 + 13.229350449*c.eday - 0.003212*c.capt2
+	mmoon := 296.104608 + 13.0649924465*c.eday + 9.192e-3*c.capt2
+	mven := (212.448 + 1.602121635*c.eday) * radian
+	merth := (358.476 + 0.985600267*c.eday) * radian
+	mmars := (319.59 + 0.524024095*c.eday) * radian
+	mjup := (225.269 + 0.083082362*c.eday) * radian
+	msat := (175.593 + 0.033450794*c.eday) * radian
+	dmoon = math.Mod(dmoon, 360) * radian
+	gmoon = math.Mod(gmoon, 360) * radian
+	mmoon = math.Mod(mmoon, 360) * radian
+	anom += cosAdd(sunf[0], sunc[0], []float64{mmars, merth, mven, mjup}) / 3600
+	anom += sinAdd(sunf[1], sunc[1], []float64{mmars, merth, mven, mjup, 0.07884 * c.capt}) / 3600
+	anom = math.Mod(anom, 360) * radian
+	// Computation of elliptic orbit.
+	c.lambda = anom + argp
+	pturbl := (6910.057-17.24*c.capt-0.052*c.capt2)*math.Sin(anom) +
+		(72.338-0.361*c.capt)*math.Sin(2.*anom) +
+		(1.054-0.001*c.capt)*math.Sin(3*anom) + 0.018*math.Sin(4*anom)
+	c.lambda += pturbl * radsec
+	c.beta = 0
+	lograd := (30.57e-6 - 0.15e-6*c.capt) -
+		(7274.12e-6-18.14e-6*c.capt-0.05e-6*c.capt2)*math.Cos(anom) -
+		(91.38e-6-0.46e-6*c.capt)*math.Cos(2*anom) -
+		(1.45e-6-0.01e-6*c.capt)*math.Cos(3*anom) -
+		0.02e-6*math.Cos(4*anom)
+	pturbl = cosAdd(sunf[2], sunc[2], []float64{mmars, merth, mven, mjup, msat})
+	pturbl += sinAdd(sunf[3], sunc[3], []float64{dmoon, mmoon, merth}) + 0.9
+	pturbl *= radsec
+	pturbb := cosAdd(sunf[4], sunc[4], []float64{merth, mven, mjup})
+	pturbb += sinAdd(sunf[5], sunc[5], []float64{gmoon, mmoon, dmoon})
+	pturbb *= radsec
+	pturbr := cosAdd(sunf[6], sunc[6], []float64{mmars, merth, mven, mjup, msat})
+	pturbr += cosAdd(sunf[7], sunc[7], []float64{dmoon, mmoon, merth})
+	c.lambda += pturbl
+	if c.lambda > twoPi {
+		c.lambda -= twoPi
+	}
+	c.beta += pturbb
+	lograd = (lograd + pturbr) * 2.30258509
+	c.rad = 1 + lograd*(1+lograd*(0.5+lograd/6))
+	c.motion *= radian / (c.rad * c.rad)
+	c.semi = 961.182
+	if c.occultMode {
+		c.semi = 959.63
+	}
+	c.mag = -26.5
+}
+
+// helio converts from ecliptic heliocentric coordinates referred to the mean
+// equinox of date to equatorial geocentric coordinates referred to the true
+// equator and equinox.
+func (c *state) helio() {
+	// Compute geocentric distance of object and light-time correction.
+	xmp := c.rad * math.Cos(c.beta) * math.Cos(c.lambda)
+	ymp := c.rad * math.Cos(c.beta) * math.Sin(c.lambda)
+	zmp := c.rad * math.Sin(c.beta)
+	rp := math.Sqrt((xmp+c.xms)*(xmp+c.xms) + (ymp+c.yms)*(ymp+c.yms) + (zmp+c.zms)*(zmp+c.zms))
+	c.lmb2 = c.lambda - 0.0057756e0*rp*c.motion
+	xmp = c.rad * math.Cos(c.beta) * math.Cos(c.lmb2)
+	ymp = c.rad * math.Cos(c.beta) * math.Sin(c.lmb2)
+	zmp = c.rad * math.Sin(c.beta)
+	// Compute annual parallax from the position of the sun.
+	xmp += c.xms
+	ymp += c.yms
+	zmp += c.zms
+	rp = math.Sqrt(xmp*xmp + ymp*ymp + zmp*zmp)
+	// Compute annual aberration from the orbital velocity of the earth (by an incorrect method).
+	xmp -= c.xdot * rp
+	ymp -= c.ydot * rp
+	zmp -= c.zdot * rp
+	// Perform the nutation and so convert from the mean equator and equinox to the true.
+	c.lmb2 = math.Atan2(ymp, xmp)
+	beta2 := math.Atan2(zmp, math.Sqrt(xmp*xmp+ymp*ymp))
+	c.lmb2 += c.phi
+	// Change to equatorial coordinates.
+	xmp = rp * math.Cos(c.lmb2) * math.Cos(beta2)
+	ymp = rp * (math.Sin(c.lmb2)*math.Cos(beta2)*math.Cos(c.tobliq) - math.Sin(c.tobliq)*math.Sin(beta2))
+	zmp = rp * (math.Sin(c.lmb2)*math.Cos(beta2)*math.Sin(c.tobliq) + math.Cos(c.tobliq)*math.Sin(beta2))
+	c.alpha = math.Atan2(ymp, xmp)
+	c.delta = math.Atan2(zmp, math.Sqrt(xmp*xmp+ymp*ymp))
+	c.hp = 8.794e0 * radsec / rp
+	c.semi /= rp
+	if c.rad > 0 && c.rad < 2e5 {
+		c.mag += 2.17 * math.Log(c.rad*rp)
+	}
+}
+
+// geo converts geocentric equatorial coordinates to topocentric equatorial and
+// topocentric horizon coordinates. All are (usually) referred to the true equator.
+func (c *state) geo() {
+	// Convert to local hour angle and declination.
+	c.lha = c.gst - c.alpha - c.wlong
+	// Compute diurnal parallax (requires geocentric latitude).
+	sa := math.Cos(c.delta) * math.Sin(c.lha)
+	ca := math.Cos(c.delta)*math.Cos(c.lha) - c.erad*math.Cos(c.glat)*math.Sin(c.hp)
+	sd := math.Sin(c.delta) - c.erad*math.Sin(c.glat)*math.Sin(c.hp)
+	c.lha = math.Atan2(sa, ca)
+	c.decl2 = math.Atan2(sd, math.Sqrt(sa*sa+ca*ca))
+	f := math.Sqrt(sa*sa + ca*ca + sd*sd)
+	c.semi2 = c.semi / f
+	c.ra = c.gst - c.lha - c.wlong
+	c.ra = piNorm(c.ra)
+	// Convert to horizon coordinates.
+	sel := math.Sin(c.nlat)*math.Sin(c.decl2) + math.Cos(c.nlat)*math.Cos(c.decl2)*math.Cos(c.lha)
+	c.el = math.Atan2(sel, pyth(sel)) / radian
+	saz := math.Sin(c.lha) * math.Cos(c.decl2)
+	caz := math.Cos(c.nlat)*math.Sin(c.decl2) - math.Sin(c.nlat)*math.Cos(c.decl2)*math.Cos(c.lha)
+	c.az = (math.Pi + math.Atan2(saz, -caz)) / radian
+}
+
+func piNorm(a float64) float64 {
+	return math.Mod(a+math.Pi, 2*math.Pi) - math.Pi
+}
+
+func pyth(x float64) float64 {
+	return math.Sqrt(1 - min(x*x, 1))
+}
+
+func (c *state) moon() {
+	// The fundamental elements - all referred to the epoch of Jan 0.5, 1900 and
+	// to the mean equinox of date.
+	dlong := 270.434164 + 13.1763965268*c.eday - 0.001133*c.capt2 + 2e-6*c.capt3
+	argp := 334.329556 + 0.1114040803*c.eday - 0.010325*c.capt2 - 12e-6*c.capt3
+	node := 259.183275 - 0.0529539222*c.eday + 0.002078*c.capt2 + 2e-6*c.capt3
+	lsun := 279.696678 + 0.9856473354*c.eday + 0.000303*c.capt2
+	psun := 281.220833 + 0.0000470684*c.eday + 0.000453*c.capt2 + 3e-6*c.capt3
+	dlong = math.Mod(dlong, 360)
+	argp = math.Mod(argp, 360)
+	node = math.Remainder(node, 360)
+	lsun = math.Mod(lsun, 360)
+	psun = math.Mod(psun, 360)
+	eccm := 22639.55
+	eccs := 0.01675104 - 0.0000418*c.capt
+	cpe := 124.986
+	chp := 3422.451
+	// Some subsidiary elements - they are all longitudes and they are referred
+	// to the epoch 1/0.5 1900 and to the fixed mean equinox of 1850.0.
+	v0 := 342.069128 + 1.602130482*c.eday
+	t0 := 98.998753 + 0.9856091138*c.eday
+	m0 := 293.049675 + 0.5240329445*c.eday
+	j0 := 237.352319 + 0.0830912295*c.eday
+	// The following are periodic corrections to the fundamental elements and constants.
+	arg1 := (41.1 + 20.2*(c.capt+0.5)) * radian
+	arg2 := (dlong - argp + 33 + 3*t0 - 10*v0 - 2.6*(c.capt+0.5)) * radian
+	arg3 := (dlong - argp + 151.1 + 16*t0 - 18*v0 - (c.capt + 0.5)) * radian // The "Great Venus Inequality".
+	arg4 := node * radian
+	arg5 := (node + 276.2 - 2.3*(c.capt+0.5)) * radian
+	arg6 := (313.9 + 13*t0 - 8*v0) * radian
+	arg7 := (dlong - argp + 112 + 29*t0 - 26*v0) * radian
+	arg8 := (dlong + argp - 2*lsun + 273 + 21*t0 - 20*v0) * radian
+	arg9 := (node + 290.1 - 0.9*(c.capt+0.5)) * radian
+	arg10 := (115 + 38.5*(c.capt+0.5)) * radian
+	dlong += (0.84*math.Sin(arg1) + 0.31*math.Sin(arg2) + 14.27*math.Sin(arg3) + 7.261*math.Sin(arg4) +
+		0.282*math.Sin(arg5) + 0.237*math.Sin(arg6) + 0.108*math.Sin(arg7) + 0.126*math.Sin(arg8)) / 3600
+	argp += (-2.1*math.Sin(arg1) - 0.118*math.Sin(arg3) - 2.076*math.Sin(arg4) - 0.84*math.Sin(arg5) - 0.593*math.Sin(arg6)) / 3600
+	node += (0.63*math.Sin(arg1) + 0.17*math.Sin(arg3) + 95.96*math.Sin(arg4) + 15.58*math.Sin(arg5) + 1.86*math.Sin(arg9)) / 3600
+	t0 += (-6.4*math.Sin(arg1) - 1.89*math.Sin(arg6)) / 3600
+	psun += (6.4*math.Sin(arg1) + 1.89*math.Sin(arg6)) / 3600
+	dgamma := -4.318*math.Cos(arg4) - 0.698*math.Cos(arg5) - 0.083*math.Cos(arg9)
+	j0 += 0.33 * math.Sin(arg10)
+	// The following factors account for the fact that the eccentricity, solar eccentricity,
+	// inclination and parallax used by Brown to make up his coefficients are
+	// both wrong and out of date. Brown did the same thing in a different way.
+	c.k1 = eccm / 22639.5
+	c.k2 = eccs / 0.01675104
+	c.k3 = 1 + 2.708e-6 + 0.000108008*dgamma
+	c.k4 = cpe / 125.154
+	k5 := chp / 3422.7
+	// The principal arguments that are used to compute perturbations are the following
+	// differences of the fundamental elements.
+	c.mnom = dlong - argp
+	c.msun = lsun - psun
+	c.noded = dlong - node
+	c.dmoon = dlong - lsun
+	// Solar terms in longitude.
+	var lterms float64
+	for _, t := range moonTabs[0] {
+		lterms += c.sinx(t.f, t.c[0], t.c[1], t.c[2], t.c[3], 0)
+	}
+	// Planetary terms in longitude.
+	lterms += c.sinx(0.822, 0, 0, 0, 0, t0-v0)
+	lterms += c.sinx(0.307, 0, 0, 0, 0, 2*t0-2*v0+179.8)
+	lterms += c.sinx(0.348, 0, 0, 0, 0, 3*t0-2*v0+272.9)
+	lterms += c.sinx(0.176, 0, 0, 0, 0, 4*t0-3*v0+271.7)
+	lterms += c.sinx(0.092, 0, 0, 0, 0, 5*t0-3*v0+199)
+	lterms += c.sinx(0.129, 1, 0, 0, 0, -t0+v0+180)
+	lterms += c.sinx(0.152, 1, 0, 0, 0, t0-v0)
+	lterms += c.sinx(0.127, 1, 0, 0, 0, 3*t0-3*v0+180)
+	lterms += c.sinx(0.099, 0, 0, 0, 2, t0-v0)
+	lterms += c.sinx(0.136, 0, 0, 0, 2, 2*t0-2*v0+179.5)
+	lterms += c.sinx(0.083, -1, 0, 0, 2, -4*t0+4*v0+180)
+	lterms += c.sinx(0.662, -1, 0, 0, 2, -3*t0+3*v0+180)
+	lterms += c.sinx(0.137, -1, 0, 0, 2, -2*t0+2*v0)
+	lterms += c.sinx(0.133, -1, 0, 0, 2, t0-v0)
+	lterms += c.sinx(0.157, -1, 0, 0, 2, 2*t0-2*v0+179.6)
+	lterms += c.sinx(0.079, -1, 0, 0, 2, -8*t0+6*v0+162.6)
+	lterms += c.sinx(0.073, 2, 0, 0, -2, 3*t0-3*v0+180)
+	lterms += c.sinx(0.643, 0, 0, 0, 0, -t0+j0+178.8)
+	lterms += c.sinx(0.187, 0, 0, 0, 0, -2*t0+2*j0+359.6)
+	lterms += c.sinx(0.087, 0, 0, 0, 0, j0+289.9)
+	lterms += c.sinx(0.165, 0, 0, 0, 0, -t0+2*j0+241.5)
+	lterms += c.sinx(0.144, 1, 0, 0, 0, t0-j0+1)
+	lterms += c.sinx(0.158, 1, 0, 0, 0, -t0+j0+179)
+	lterms += c.sinx(0.19, 1, 0, 0, 0, -2*t0+2*j0+180)
+	lterms += c.sinx(0.096, 1, 0, 0, 0, -2*t0+3*j0+352.5)
+	lterms += c.sinx(0.07, 0, 0, 0, 2, 2*t0-2*j0+180)
+	lterms += c.sinx(0.167, 0, 0, 0, 2, -t0+j0+178.5)
+	lterms += c.sinx(0.085, 0, 0, 0, 2, -2*t0+2*j0+359.2)
+	lterms += c.sinx(1.137, -1, 0, 0, 2, 2*t0-2*j0+180.3)
+	lterms += c.sinx(0.211, -1, 0, 0, 2, -t0+j0+178.4)
+	lterms += c.sinx(0.089, -1, 0, 0, 2, -2*t0+2*j0+359.2)
+	lterms += c.sinx(0.436, -1, 0, 0, 2, 2*t0-3*j0+7.5)
+	lterms += c.sinx(0.24, 2, 0, 0, -2, -2*t0+2*j0+179.9)
+	lterms += c.sinx(0.284, 2, 0, 0, -2, -2*t0+3*j0+172.5)
+	lterms += c.sinx(0.195, 0, 0, 0, 0, -2*t0+2*m0+180.2)
+	lterms += c.sinx(0.327, 0, 0, 0, 0, -t0+2*m0+224.4)
+	lterms += c.sinx(0.093, 0, 0, 0, 0, -2*t0+4*m0+244.8)
+	lterms += c.sinx(0.073, 1, 0, 0, 0, -t0+2*m0+223.3)
+	lterms += c.sinx(0.074, 1, 0, 0, 0, t0-2*m0+306.3)
+	lterms += c.sinx(0.189, 0, 0, 0, 0, node+180)
+	// Solar terms in latitude.
+	var sterms float64
+	for _, t := range moonTabs[1] {
+		sterms += c.sinx(t.f, t.c[0], t.c[1], t.c[2], t.c[3], 0)
+	}
+	var cterms float64
+	for _, t := range moonTabs[2] {
+		cterms += c.cosx(t.f, t.c[0], t.c[1], t.c[2], t.c[3], 0)
+	}
+	var nterms float64
+	for _, t := range moonTabs[3] {
+		nterms += c.sinx(t.f, t.c[0], t.c[1], t.c[2], t.c[3], 0)
+	}
+	// Planetary terms in latitude.
+	pterms := c.sinx(0.215, 0, 0, 0, 0, dlong)
+	// Solar terms in parallax.
+	spterms := 3422.7
+	for _, t := range moonTabs[4] {
+		spterms += c.cosx(t.f, t.c[0], t.c[1], t.c[2], t.c[3], 0)
+	}
+	// Computation of longitude.
+	c.lambda = (dlong + lterms/3600) * radian
+	// Computation of latitude.
+	arglat := (c.noded + sterms/3600) * radian
+	gamma1 := 18519.7 * c.k3
+	gamma2 := -6.241 * c.k3 * c.k3 * c.k3
+	gamma3 := 0.004 * c.k3 * c.k3 * c.k3 * c.k3 * c.k3
+	k6 := (gamma1 + cterms) / gamma1
+	c.beta = k6*(gamma1*math.Sin(arglat)+gamma2*math.Sin(3.*arglat)+gamma3*math.Sin(5.*arglat)+nterms) + pterms
+	if c.occultMode {
+		c.beta -= 0.6
+	}
+	c.beta *= radsec
+	// Computation of parallax.
+	spterms = k5 * spterms * radsec
+	c.hp = spterms + (spterms*spterms*spterms)/6
+	c.rad = c.hp / radsec
+	c.semi = 0.0799 + 0.272453*(c.hp/radsec)
+	if c.dmoon < 0 {
+		c.dmoon += 360
+	}
+	// The magnitude, from the phase angle and the distances of the sun
+	// and the moon.
+	ls := math.Atan2(c.yms, c.xms)
+	bs := math.Atan2(c.zms, math.Hypot(c.xms, c.yms))
+	ce := math.Cos(c.beta)*math.Cos(bs)*math.Cos(c.lambda-ls) + math.Sin(c.beta)*math.Sin(bs)
+	d := math.Sin(8.794*radsec) / math.Sin(c.hp)
+	i := math.Atan2(c.srad*math.Sqrt(1-ce*ce), d-c.srad*ce) / radian
+	c.mag = -12.73 + 0.026*i + 4e-9*i*i*i*i + 5*math.Log10(d/0.0025696)
+	// Change to equatorial coordinates.
+	c.lambda += c.phi
+	c.lmb2 = c.lambda
+	obl2 := c.obliq + c.eps
+	xmp := math.Cos(c.lambda) * math.Cos(c.beta)
+	ymp := math.Sin(c.lambda)*math.Cos(c.beta)*math.Cos(obl2) - math.Sin(obl2)*math.Sin(c.beta)
+	zmp := math.Sin(c.lambda)*math.Cos(c.beta)*math.Sin(obl2) + math.Cos(obl2)*math.Sin(c.beta)
+	c.alpha = math.Atan2(ymp, xmp)
+	c.delta = math.Atan2(zmp, math.Sqrt(xmp*xmp+ymp*ymp))
+	c.meday = c.eday
+	c.mhp = c.hp
+	c.geo()
+}
+
+func (c *state) sinx(coef float64, i, j, k, m int, angle float64) float64 {
+	return c.trigx(coef, i, j, k, m, angle, math.Sin)
+}
+
+func (c *state) cosx(coef float64, i, j, k, m int, angle float64) float64 {
+	return c.trigx(coef, i, j, k, m, angle, math.Cos)
+}
+
+func (c *state) trigx(coef float64, i, j, k, m int, angle float64, f func(float64) float64) float64 {
+	x := coef * f((float64(i)*c.mnom+float64(j)*c.msun+float64(k)*c.noded+float64(m)*c.dmoon+angle)*radian)
+	for i := abs(i); i > 0; i-- {
+		x *= c.k1
+	}
+	for j := abs(j); j > 0; j-- {
+		x *= c.k2
+	}
+	for k := abs(k); k > 0; k-- {
+		x *= c.k3
+	}
+	if m&1 > 0 {
+		x *= c.k4
+	}
+	return x
+}
+
+func abs(x int) int {
+	if x < 0 {
+		x = -x
+	}
+	return x
+}
+
+func (c *state) shad() {
+	if c.seday != c.eday {
+		c.fSun()
+	}
+	if c.meday != c.eday {
+		c.moon()
+	}
+	c.alpha = math.Mod(c.salph+math.Pi, twoPi)
+	c.delta = -c.sdelt
+	c.hp = c.mhp
+	c.semi = 1.0183*c.mhp/radsec - 969.85/c.srad
+	c.geo()
+}
+
+func (c *state) merc() {
+	ecc := 0.20561421 + 0.00002046*c.capt - 0.03e-6*c.capt2
+	incl := (7.0028806 + 0.0018608*c.capt - 18.3e-6*c.capt2) * radian
+	node := (47.145944 + 1.185208*c.capt + 0.0001739*c.capt2) * radian
+	argp := (75.899697 + 1.55549*c.capt + 0.0002947*c.capt2) * radian
+	mrad := 0.3870986
+	anom := 102.279381 + 4.0923344364*c.eday + 6.7e-6*c.capt2
+	c.motion = 4.0923770233
+	q0 := (102.28 + 4.092334429*c.eday) * radian
+	v0 := (212.536 + 1.602126105*c.eday) * radian
+	t0 := (-1.45 + 0.985604737*c.eday) * radian
+	j0 := (225.36 + 0.083086735*c.eday) * radian
+	s0 := (175.68 + 0.033455441*c.eday) * radian
+	anom = math.Mod(anom, 360) * radian
+	enom := anom + ecc*math.Sin(anom)
+	for {
+		dele := (anom - enom + ecc*math.Sin(enom)) / (1 - ecc*math.Cos(enom))
+		enom += dele
+		if math.Abs(dele) <= converge {
+			break
+		}
+	}
+	vnom := 2 * math.Atan2(math.Sqrt((1+ecc)/(1-ecc))*math.Sin(enom/2), math.Cos(enom/2))
+	c.rad = mrad * (1 - ecc*math.Cos(enom))
+	pturbl := cosAdd(mercf[0], mercc[0], []float64{q0, -v0})
+	pturbl += cosAdd(mercf[1], mercc[1], []float64{q0, -t0})
+	pturbl += cosAdd(mercf[2], mercc[2], []float64{q0, -j0})
+	pturbl += cosAdd(mercf[3], mercc[3], []float64{q0, -s0})
+	pturbr := cosAdd(mercf[4], mercc[4], []float64{q0, -v0})
+	pturbr += cosAdd(mercf[5], mercc[5], []float64{q0, -t0})
+	pturbr += cosAdd(mercf[6], mercc[6], []float64{q0, -j0})
+	// Reduce to the ecliptic.
+	c.lambda = vnom + argp + pturbl*radsec
+	nd := c.lambda - node
+	c.lambda = node + math.Atan2(math.Sin(nd)*math.Cos(incl), math.Cos(nd))
+	sl := math.Sin(incl) * math.Sin(nd)
+	c.beta = math.Atan2(sl, pyth(sl))
+	lograd := pturbr * 2.30258509
+	c.rad *= 1 + lograd
+	c.motion *= radian * mrad * mrad / (c.rad * c.rad)
+	c.semi = 3.34
+	lsun := (99.696678 + 0.9856473354*c.eday) * radian
+	elong := c.lambda - lsun
+	ci := (c.rad - math.Cos(elong)) / math.Sqrt(1+c.rad*c.rad-2*c.rad*math.Cos(elong))
+	dlong := math.Atan2(pyth(ci), ci) / radian
+	c.mag = -0.003 + 0.01815*dlong + 0.0001023*dlong*dlong
+	c.helio()
+	c.geo()
+}
+
+func cosAdd(caf []float64, cac []int, coefs []float64) float64 {
+	return trigAdd(math.Cos, caf, cac, coefs)
+}
+
+func sinAdd(caf []float64, cac []int, coefs []float64) float64 {
+	return trigAdd(math.Sin, caf, cac, coefs)
+}
+
+func trigAdd(f func(float64) float64, caf []float64, cac []int, coefs []float64) float64 {
+	var sum float64
+	var j int
+	for i := 0; i < len(caf); i += 2 {
+		a1, a2 := caf[i], caf[i+1]
+		for _, c := range coefs {
+			a2 += float64(cac[j]) * c
+			j++
+		}
+		sum += a1 * f(a2)
+	}
+	return sum
+}
+
+func (c *state) venus() {
+	// Mean orbital elements.
+	ecc := 0.00682069 - 0.00004774*c.capt + 0.091e-6*c.capt2
+	incl := (3.393631 + 0.0010058*c.capt - 0.97e-6*c.capt2) * radian
+	node := (75.779647 + 0.89985*c.capt + 0.00041*c.capt2) * radian
+	argp := (130.163833 + 1.408036*c.capt - 0.0009763*c.capt2) * radian
+	mrad := 0.7233316
+	anom := 212.603219 + 1.602130154*c.eday + 0.00128605*c.capt2
+	c.motion = 1.6021687039
+	// Mean anomalies of perturbing planets.
+	v0 := (212.6 + 1.602130154*c.eday) * radian
+	t0 := (358.63 + 0.985608747*c.eday) * radian
+	m0 := (319.74 + 0.52403249*c.eday) * radian
+	j0 := (225.43 + 0.083090842*c.eday) * radian
+	s0 := (175.8 + 0.033459258*c.eday) * radian
+	anom = math.Mod(anom, 360) * radian
+	// Computation of long period terms affecting the mean anomaly.
+	anom += (2.761-0.022*c.capt)*radsec*math.Sin(13*t0-8*v0+43.83*radian+4.52*radian*c.capt) +
+		0.268*radsec*math.Cos(4*m0-7*t0+3*v0) +
+		0.019*radsec*math.Sin(4*m0-7*t0+3*v0) -
+		0.208*radsec*math.Sin(s0+1.4*radian*c.capt)
+		// Computation of elliptic orbit.
+	enom := anom + ecc*math.Sin(anom)
+	for {
+		dele := (anom - enom + ecc*math.Sin(enom)) / (1 - ecc*math.Cos(enom))
+		enom += dele
+		if math.Abs(dele) <= converge {
+			break
+		}
+	}
+	vnom := 2 * math.Atan2(math.Sqrt((1+ecc)/(1-ecc))*math.Sin(enom/2), math.Cos(enom/2))
+	c.rad = mrad * (1 - ecc*math.Cos(enom))
+	c.lambda = vnom + argp
+	// Perturbations in longitude.
+	pturbl := cosAdd(venf[0], venc[0], []float64{v0, t0, m0, j0}) * radsec
+	// Perturbations in latitude.
+	pturbb := cosAdd(venf[1], venc[1], []float64{v0, t0, j0}) * radsec
+	// Perturbations in log radius vector.
+	pturbr := cosAdd(venf[2], venc[2], []float64{v0, t0, m0, j0})
+	// Reduction to the ecliptic.
+	c.lambda += pturbl
+	nd := c.lambda - node
+	c.lambda = node + math.Atan2(math.Sin(nd)*math.Cos(incl), math.Cos(nd))
+	sl := math.Sin(incl) * math.Sin(nd)
+	c.beta = math.Atan2(sl, pyth(sl)) + pturbb
+	lograd := pturbr * 2.30258509
+	c.rad *= 1 + lograd
+	c.motion *= radian * mrad * mrad / (c.rad * c.rad)
+	// Computation of magnitude.
+	lsun := (99.696678 + 0.9856473354*c.eday) * radian
+	elong := c.lambda - lsun
+	ci := (c.rad - math.Cos(elong)) / math.Sqrt(1+c.rad*c.rad-2*c.rad*math.Cos(elong))
+	dlong := math.Atan2(pyth(ci), ci) / radian
+	c.mag = -4 + 0.01322*dlong + 0.0000004247*dlong*dlong*dlong
+	c.semi = 8.41
+	c.helio()
+	c.geo()
+}
+
+func (c *state) mars() {
+	ecc := 0.0933129 + 0.000092064*c.capt
+	incl := (1.850333 - 6.75e-4*c.capt) * radian
+	node := (48.786442 + 0.770992*c.capt) * radian
+	argp := (334.218203 + 1.840758*c.capt + 1.3e-4*c.capt2) * radian
+	mrad := 1.5236915
+	anom := 319.529425 + 0.5240207666*c.eday + 1.808e-4*c.capt2
+	c.motion = 0.5240711638
+	anom = math.Mod(anom, 360) * radian
+	enom := anom + ecc*math.Sin(anom)
+	for {
+		dele := (anom - enom + ecc*math.Sin(enom)) / (1 - ecc*math.Cos(enom))
+		enom += dele
+		if math.Abs(dele) <= converge {
+			break
+		}
+	}
+	vnom := 2 * math.Atan2(math.Sqrt((1+ecc)/(1-ecc))*math.Sin(enom/2), math.Cos(enom/2))
+	c.rad = mrad * (1 - ecc*math.Cos(enom))
+	c.lambda = vnom + argp
+	// Reduce to the ecliptic.
+	nd := c.lambda - node
+	c.lambda = node + math.Atan2(math.Sin(nd)*math.Cos(incl), math.Cos(nd))
+	sl := math.Sin(incl) * math.Sin(nd)
+	c.beta = math.Atan2(sl, pyth(sl))
+	c.motion *= radian * mrad * mrad / (c.rad * c.rad)
+	c.semi = 4.68
+	lsun := (99.696678 + 0.9856473354*c.eday) * radian
+	elong := c.lambda - lsun
+	ci := (c.rad - math.Cos(elong)) / math.Sqrt(1+c.rad*c.rad-2*c.rad*math.Cos(elong))
+	dlong := math.Atan2(pyth(ci), ci) / radian
+	c.mag = -1.3 + 0.01486*dlong
+	c.helio()
+	c.geo()
+}
+
+func (c *state) jup() {
+	ecc := 0.0483376 + 163e-6*c.capt
+	incl := (1.30866 - 0.0055*c.capt) * radian
+	node := (99.43785 + 1.011*c.capt) * radian
+	argp := (12.71165 + 1.611*c.capt) * radian
+	mrad := 5.202803
+	anom := 225.22165 + 0.0830912*c.eday - 0.0484*c.capt
+	c.motion = 299.1284 / 3600
+	anom = math.Mod(anom, 360) * radian
+	enom := anom + ecc*math.Sin(anom)
+	for {
+		dele := (anom - enom + ecc*math.Sin(enom)) / (1 - ecc*math.Cos(enom))
+		enom += dele
+		if math.Abs(dele) <= converge {
+			break
+		}
+	}
+	vnom := 2 * math.Atan2(math.Sqrt((1+ecc)/(1-ecc))*math.Sin(enom/2), math.Cos(enom/2))
+	c.rad = mrad * (1 - ecc*math.Cos(enom))
+	c.lambda = vnom + argp
+	// Reduce to the ecliptic.
+	nd := c.lambda - node
+	c.lambda = node + math.Atan2(math.Sin(nd)*math.Cos(incl), math.Cos(nd)) + 555*radsec
+	sl := math.Sin(incl) * math.Sin(nd)
+	c.beta = math.Atan2(sl, pyth(sl)) - 51*radsec
+	c.motion *= radian * mrad * mrad / (c.rad * c.rad)
+	c.semi = 98.47
+	c.mag = -9.40
+	c.helio()
+	c.geo()
+}
+
+func (c *state) sat() {
+	ecc := 0.05589 - 0.000347*c.capt
+	incl := (2.49256 - 0.0044*c.capt) * radian
+	node := (112.78364 + 0.87306*c.capt) * radian
+	argp := (91.08897 + 1.95917*c.capt) * radian
+	mrad := 9.538843
+	anom := 175.4763 + 0.03345972*c.eday - 0.56527*c.capt
+	c.motion = 120.455 / 3600
+	anom = math.Mod(anom, 360) * radian
+	enom := anom + ecc*math.Sin(anom)
+	for {
+		dele := (anom - enom + ecc*math.Sin(enom)) / (1 - ecc*math.Cos(enom))
+		enom += dele
+		if math.Abs(dele) <= converge {
+			break
+		}
+	}
+	vnom := 2 * math.Atan2(math.Sqrt((1+ecc)/(1-ecc))*math.Sin(enom/2), math.Cos(enom/2))
+	c.rad = mrad * (1 - ecc*math.Cos(enom))
+	c.lambda = vnom + argp
+	// Reduce to the ecliptic.
+	nd := c.lambda - node
+	c.lambda = node + math.Atan2(math.Sin(nd)*math.Cos(incl), math.Cos(nd)) - 1185*radsec
+	sl := math.Sin(incl) * math.Sin(nd)
+	c.beta = math.Atan2(sl, pyth(sl)) - 51*radsec
+	c.motion *= radian * mrad * mrad / (c.rad * c.rad)
+	c.semi = 83.33
+	// Computation of magnitude; first, find the geocentric equatorial coordinates of Saturn.
+	// The position of the sun is ecliptic, so add it before the rotation.
+	xe := c.rad*math.Cos(c.beta)*math.Cos(c.lambda) + c.xms
+	ye := c.rad*math.Cos(c.beta)*math.Sin(c.lambda) + c.yms
+	ze := c.rad*math.Sin(c.beta) + c.zms
+	sd := ye*math.Sin(c.obliq) + ze*math.Cos(c.obliq)
+	sa := ye*math.Cos(c.obliq) - ze*math.Sin(c.obliq)
+	ca := xe
+	c.alpha = math.Atan2(sa, ca)
+	c.delta = math.Atan2(sd, math.Sqrt(sa*sa+ca*ca))
+	// Here are the necessary elements of Saturn's rings cf. Exp. Supp. p. 363ff.
+	capj := (6.9056 - 0.4322*c.capt) * radian
+	capn := (126.3615 + 3.9894*c.capt + 0.2403*c.capt2) * radian
+	eye := (28.0743 - 0.0128*c.capt) * radian
+	comg := (168.1179 + 1.3936*c.capt) * radian
+	omg := (42.9236 - 2.739*c.capt - 0.2344*c.capt2) * radian
+	// Now find saturnicentric ring-plane coords of the earth.
+	sb := math.Sin(capj)*math.Cos(c.delta)*math.Sin(c.alpha-capn) - math.Cos(capj)*math.Sin(c.delta)
+	su := math.Cos(capj)*math.Cos(c.delta)*math.Sin(c.alpha-capn) + math.Sin(capj)*math.Sin(c.delta)
+	cu := math.Cos(c.delta) * math.Cos(c.alpha-capn)
+	u := math.Atan2(su, cu)
+	b := math.Atan2(sb, math.Sqrt(su*su+cu*cu))
+	// And then the saturnicentric ring-plane coords of the sun.
+	su = math.Sin(eye)*math.Sin(c.beta) + math.Cos(eye)*math.Cos(c.beta)*math.Sin(c.lambda-comg)
+	cu = math.Cos(c.beta) * math.Cos(c.lambda-comg)
+	up := math.Atan2(su, cu)
+	sb = math.Sin(eye)*math.Cos(c.beta)*math.Sin(c.lambda-comg) - math.Cos(eye)*math.Sin(c.beta)
+	c.ringB = b
+	c.ringBSun = math.Asin(sb)
+	// The position angle of the pole of the ring plane, at right ascension
+	// capn-90° and declination 90°-capj.
+	c.ringP = math.Atan2(-math.Sin(capj)*math.Cos(capn-c.alpha), math.Cos(capj)*math.Cos(c.delta)-math.Sin(capj)*math.Sin(c.delta)*math.Sin(capn-c.alpha))
+	// At last, the magnitude.
+	sb = math.Sin(b)
+	c.mag = -8.68 + 2.52*math.Abs(math.Remainder(up+omg-u, twoPi)) - 2.6*math.Abs(sb) + 1.25*(sb*sb)
+	c.helio()
+	c.geo()
+}
+
+func (c *state) uran() {
+	cy := (c.eday - elemUran[0]) / 36525 // Per julian century.
+	mrad := elemUran[1] + elemUran[1+6]*cy
+	ecc := elemUran[2] + elemUran[2+6]*cy
+	cy = cy / 3600 // arcsec/deg per julian century.
+	incl := (elemUran[3] + elemUran[3+6]*cy) * radian
+	node := (elemUran[4] + elemUran[4+6]*cy) * radian
+	argp := (elemUran[5] + elemUran[5+6]*cy)
+	anom := elemUran[6] + elemUran[6+6]*cy - argp
+	c.motion = elemUran[6+6] / 36525 / 3600
+	argp *= radian
+	anom = math.Mod(anom, 360) * radian
+	enom := anom + ecc*math.Sin(anom)
+	for {
+		dele := (anom - enom + ecc*math.Sin(enom)) / (1 - ecc*math.Cos(enom))
+		enom += dele
+		if math.Abs(dele) <= converge {
+			break
+		}
+	}
+	vnom := 2 * math.Atan2(math.Sqrt((1+ecc)/(1-ecc))*math.Sin(enom/2), math.Cos(enom/2))
+	c.rad = mrad * (1 - ecc*math.Cos(enom))
+	c.lambda = vnom + argp
+	// Reduce to the ecliptic.
+	nd := c.lambda - node
+	c.lambda = node + math.Atan2(math.Sin(nd)*math.Cos(incl), math.Cos(nd)) - 1185*radsec
+	sl := math.Sin(incl) * math.Sin(nd)
+	c.beta = math.Atan2(sl, pyth(sl)) - 51*radsec
+	c.motion *= radian * mrad * mrad / (c.rad * c.rad)
+	c.semi = 83.33
+	// Magnitude at unit distances from the sun and the earth.
+	c.mag = -7.19
+	c.helio()
+	c.geo()
+}
+
+func (c *state) nept() {
+	cy := (c.eday - elemNept[0]) / 36525 // Per julian century.
+	mrad := elemNept[1] + elemNept[1+6]*cy
+	ecc := elemNept[2] + elemNept[2+6]*cy
+	cy = cy / 3600 // arcsec/deg per julian century.
+	incl := (elemNept[3] + elemNept[3+6]*cy) * radian
+	node := (elemNept[4] + elemNept[4+6]*cy) * radian
+	argp := (elemNept[5] + elemNept[5+6]*cy)
+	anom := elemNept[6] + elemNept[6+6]*cy - argp
+	c.motion = elemNept[6+6] / 36525 / 3600
+	argp *= radian
+	anom = math.Mod(anom, 360) * radian
+	enom := anom + ecc*math.Sin(anom)
+	for {
+		dele := (anom - enom + ecc*math.Sin(enom)) / (1 - ecc*math.Cos(enom))
+		enom += dele
+		if math.Abs(dele) <= converge {
+			break
+		}
+	}
+	vnom := 2 * math.Atan2(math.Sqrt((1+ecc)/(1-ecc))*math.Sin(enom/2), math.Cos(enom/2))
+	c.rad = mrad * (1 - ecc*math.Cos(enom))
+	c.lambda = vnom + argp
+	// Reduce to the ecliptic.
+	nd := c.lambda - node
+	c.lambda = node + math.Atan2(math.Sin(nd)*math.Cos(incl), math.Cos(nd))
+	sl := math.Sin(incl) * math.Sin(nd)
+	c.beta = math.Atan2(sl, pyth(sl))
+	c.lambda -= 1185 * radsec
+	c.beta -= 51 * radsec
+	c.motion *= radian * mrad * mrad / (c.rad * c.rad)
+	c.semi = 83.33
+	// Magnitude at unit distances from the sun and the earth.
+	c.mag = -6.87
+	c.helio()
+	c.geo()
+}
+
+func (c *state) plut() {
+	cy := (c.eday - elemPlut[0]) / 36525 // Per julian century.
+	mrad := elemPlut[1] + elemPlut[1+6]*cy
+	ecc := elemPlut[2] + elemPlut[2+6]*cy
+	cy = cy / 3600 // arcsec/deg per julian century.
+	incl := (elemPlut[3] + elemPlut[3+6]*cy) * radian
+	node := (elemPlut[4] + elemPlut[4+6]*cy) * radian
+	argp := (elemPlut[5] + elemPlut[5+6]*cy)
+	anom := elemPlut[6] + elemPlut[6+6]*cy - argp
+	c.motion = elemPlut[6+6] / 36525 / 3600
+	argp *= radian
+	anom = math.Mod(anom, 360) * radian
+	enom := anom + ecc*math.Sin(anom)
+	for {
+		dele := (anom - enom + ecc*math.Sin(enom)) / (1 - ecc*math.Cos(enom))
+		enom += dele
+		if math.Abs(dele) <= converge {
+			break
+		}
+	}
+	vnom := 2 * math.Atan2(math.Sqrt((1+ecc)/(1-ecc))*math.Sin(enom/2), math.Cos(enom/2))
+	c.rad = mrad * (1 - ecc*math.Cos(enom))
+	c.lambda = vnom + argp
+	// Reduce to the ecliptic.
+	nd := c.lambda - node
+	c.lambda = node + math.Atan2(math.Sin(nd)*math.Cos(incl), math.Cos(nd))
+	sl := math.Sin(incl) * math.Sin(nd)
+	c.beta = math.Atan2(sl, pyth(sl))
+	c.lambda -= 1185 * radsec
+	c.beta -= 51 * radsec
+	c.motion *= radian * mrad * mrad / (c.rad * c.rad)
+	c.semi = 83.33
+	// Magnitude at unit distances from the sun and the earth.
+	c.mag = -1.01
+	c.helio()
+	c.geo()
+}
+
+func (c *state) comet(elem *Comet) {
+	c.orbit(elem.Q, elem.E, elem.Peri, elem.Node, elem.Incl, c.eday-timeToJulian(elem.Perihelion))
+	c.semi = 0
+	c.helio()
+	c.geo()
+	// The magnitude depends on the distances from the sun and the earth.
+	c.mag = elem.H + 5*math.Log10(8.794*radsec/c.hp) + 2.5*elem.K*math.Log10(c.rad)
+}
+
+func (c *state) asteroid(elem *Asteroid) {
+	n := gauss / math.Sqrt(elem.A*elem.A*elem.A)
+	// Time since perihelion from the mean anomaly at the epoch.
+	dt := elem.M*radian/n + c.eday - timeToJulian(elem.Epoch)
+	c.orbit(elem.A*(1-elem.E), elem.E, elem.Peri, elem.Node, elem.Incl, dt)
+	c.semi = 0
+	c.helio()
+	c.geo()
+	// The IAU H, G magnitude system.
+	r := c.rad
+	d := 8.794 * radsec / c.hp
+	ca := (r*r + d*d - c.srad*c.srad) / (2 * r * d)
+	tb := math.Tan(math.Acos(max(-1, min(ca, 1))) / 2)
+	phi1 := math.Exp(-3.33 * math.Pow(tb, 0.63))
+	phi2 := math.Exp(-1.87 * math.Pow(tb, 1.22))
+	c.mag = elem.H + 5*math.Log10(r*d) - 2.5*math.Log10((1-elem.G)*phi1+elem.G*phi2)
+}
+
+// orbit computes the heliocentric ecliptic coordinates of a body dt days
+// after perihelion in an orbit with perihelion distance q, eccentricity ecc,
+// and argument of perihelion, longitude of the ascending node, and
+// inclination (degrees) referred to J2000.
+func (c *state) orbit(q, ecc, peri, node, incl, dt float64) {
+	// Precess the elements from J2000 to the equinox of date.
+	prec := 1.396971 * (c.capt - 1)
+	incl *= radian
+	argp := (peri + node + prec) * radian
+	node = (node + prec) * radian
+	var vnom float64
+	vnom, c.rad = kepler(q, ecc, dt)
+	c.motion = gauss * math.Sqrt(q*(1+ecc)) / (c.rad * c.rad)
+	c.lambda = vnom + argp
+	// Reduce to the ecliptic.
+	nd := c.lambda - node
+	c.lambda = node + math.Atan2(math.Sin(nd)*math.Cos(incl), math.Cos(nd))
+	sl := math.Sin(incl) * math.Sin(nd)
+	c.beta = math.Atan2(sl, math.Sqrt(1-sl*sl))
+}
+
+// gauss is the Gaussian gravitational constant (radians per day).
+const gauss = 0.01720209895
+
+// kepler returns the true anomaly and radius vector dt days after
+// perihelion in an orbit with perihelion distance q and eccentricity ecc.
+// It solves Kepler's equation in the universal variable x, which serves
+// for ellipses, parabolas, and hyperbolas alike, so that an orbit with an
+// eccentricity near 1 needs no special case.
+func kepler(q, ecc, dt float64) (v, r float64) {
+	alpha := (1 - ecc) / q // reciprocal of the semimajor axis
+	if alpha > 0 {
+		// Reduce the time to within half a period of perihelion.
+		dt = math.Remainder(dt, twoPi/(gauss*math.Pow(alpha, 1.5)))
+	}
+	tau := gauss * dt
+	// The time since perihelion increases with x, and x lies between 0 and
+	// tau/q. Take Newton's steps, bisecting when a step leaves the bracket.
+	lo, hi := min(0, tau/q), max(0, tau/q)
+	x := tau / q
+	for range 100 {
+		c, s := stumpff(alpha * x * x)
+		fx := ecc*x*x*x*s + q*x - tau
+		if fx == 0 {
+			break
+		}
+		if fx > 0 {
+			hi = x
+		} else {
+			lo = x
+		}
+		xn := x - fx/(ecc*x*x*c+q)
+		if xn <= lo || xn >= hi {
+			xn = (lo + hi) / 2
+		}
+		d := math.Abs(xn - x)
+		x = xn
+		if d <= converge*math.Max(1, math.Abs(x)) {
+			break
+		}
+	}
+	// The position in the plane of the orbit follows from the Lagrange
+	// coefficients f and g applied to the position and velocity at
+	// perihelion.
+	c, s := stumpff(alpha * x * x)
+	px := q - x*x*c
+	py := (tau - x*x*x*s) * math.Sqrt((1+ecc)/q)
+	return math.Atan2(py, px), ecc*x*x*c + q
+}
+
+// stumpff returns the Stumpff functions c2(z) and c3(z).
+func stumpff(z float64) (c, s float64) {
+	switch {
+	case z > 0.1:
+		w := math.Sqrt(z)
+		return (1 - math.Cos(w)) / z, (w - math.Sin(w)) / (z * w)
+	case z < -0.1:
+		w := math.Sqrt(-z)
+		return (math.Cosh(w) - 1) / -z, (math.Sinh(w) - w) / (-z * w)
+	}
+	// Near 0 the closed forms lose precision, so sum the series.
+	c, s = 0.5, 1./6
+	tc, ts := c, s
+	for k := 1.; k < 8; k++ {
+		tc *= -z / ((2*k + 1) * (2*k + 2))
+		ts *= -z / ((2*k + 2) * (2*k + 3))
+		c += tc
+		s += ts
+	}
+	return c, s
+}
+
+func (c *state) star() {
+	c.ra = c.oStar.point[0].RA
+	c.decl2 = c.oStar.point[0].Decl
+	c.semi2 = c.oStar.point[0].Semi
+	c.az = c.oStar.point[0].Az
+	c.el = c.oStar.point[0].El
+	c.mag = c.oStar.point[0].Mag
+	c.hp = math.Asin(8.794 * radsec / c.oStar.point[0].Dist)
+}
@@ -0,0 +1,74 @@
+// Copyright 2024 Matthew P. Dargan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ephem
+
+import (
+	"math"
+	"testing"
+)
+
+// TestKeplerBarker checks the parabolic orbit of Example 34.a of Meeus,
+// Astronomical Algorithms: 112.5642 days after perihelion at 1.487469 au,
+// the true anomaly is 66.78862° and the radius vector is 2.133911 au.
+func TestKeplerBarker(t *testing.T) {
+	v, r := kepler(1.487469, 1, 112.5642)
+	if d := math.Abs(v/radian - 66.78862); d > 1e-5 {
+		t.Errorf("v = %.5f°, want 66.78862°", v/radian)
+	}
+	if d := math.Abs(r - 2.133911); d > 1e-6 {
+		t.Errorf("r = %.6f au, want 2.133911 au", r)
+	}
+}
+
+// TestKepler checks that the anomaly and radius vector found for elliptic
+// and hyperbolic orbits lie on the conic and give back the time since
+// perihelion by the classical forms of Kepler's equation.
+func TestKepler(t *testing.T) {
+	tests := []struct {
+		name   string
+		q, ecc float64
+		dt     float64
+	}{
+		{"asteroid", 2.1, 0.15, 400},
+		{"short-period comet", 0.59, 0.967, -1500},
+		{"long-period comet", 0.5, 0.99, 300},
+		{"near-parabolic", 1.2, 1.01, -80},
+		{"1I/ʻOumuamua", 0.2555, 1.2011, 60},
+		{"C/1980 E1", 3.364, 1.0573, 2000},
+	}
+	for _, tt := range tests {
+		v, r := kepler(tt.q, tt.ecc, tt.dt)
+		if d := math.Abs(r - tt.q*(1+tt.ecc)/(1+tt.ecc*math.Cos(v))); d > 1e-9*r {
+			t.Errorf("%s: r = %v is off the conic by %v", tt.name, r, d)
+		}
+		var dt float64
+		if a := tt.q / (1 - tt.ecc); a > 0 {
+			e := 2 * math.Atan(math.Sqrt((1-tt.ecc)/(1+tt.ecc))*math.Tan(v/2))
+			dt = (e - tt.ecc*math.Sin(e)) * math.Sqrt(a*a*a) / gauss
+			dt += math.Round((tt.dt-dt)*gauss/math.Sqrt(a*a*a)/twoPi) * twoPi * math.Sqrt(a*a*a) / gauss
+		} else {
+			h := 2 * math.Atanh(math.Sqrt((tt.ecc-1)/(tt.ecc+1))*math.Tan(v/2))
+			dt = (tt.ecc*math.Sinh(h) - h) * math.Sqrt(-a*a*a) / gauss
+		}
+		if d := math.Abs(dt - tt.dt); d > 1e-6 {
+			t.Errorf("%s: v = %v gives %v days, want %v days", tt.name, v, dt, tt.dt)
+		}
+	}
+}
+
+// TestKeplerNearParabolic checks that the solution is continuous as the
+// eccentricity passes through 1, where the classical forms of Kepler's
+// equation break down, and that it is found far from perihelion.
+func TestKeplerNearParabolic(t *testing.T) {
+	for _, dt := range []float64{-1e5, -365, -10, 0, 10, 365, 1e5} {
+		v0, r0 := kepler(0.8, 1, dt)
+		for _, ecc := range []float64{1 - 1e-12, 1 - 1e-9, 1 + 1e-9, 1 + 1e-12} {
+			v, r := kepler(0.8, ecc, dt)
+			if math.Abs(v-v0) > 1e-6 || math.Abs(r-r0) > 1e-6*r0 {
+				t.Errorf("e = %v, %v days: v = %v, r = %v; at e = 1, v = %v, r = %v", ecc, dt, v, r, v0, r0)
+			}
+		}
+	}
+}
@@ -0,0 +1,276 @@
+// Copyright 2024 Matthew P. Dargan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ephem
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// A StarReader reads the stars of a star catalog. Each is converted to a
+// [Star] whose position and proper motion are referred to J2000.
+type StarReader interface {
+	// Read returns the next star, or io.EOF at the end of the catalog.
+	Read() (Star, error)
+}
+
+type estartabReader struct {
+	s *bufio.Scanner
+	n int
+}
+
+// NewEstartabReader returns a StarReader that reads the Plan 9 estartab
+// format: one star per line, with its SAO number and its FK4 mean place and
+// proper motion at B1950 in fixed columns.
+func NewEstartabReader(r io.Reader) StarReader {
+	return &estartabReader{s: bufio.NewScanner(r)}
+}
+
+func (r *estartabReader) Read() (Star, error) {
+	for r.s.Scan() {
+		r.n++
+		l := r.s.Text()
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+		s, err := parseEstartab(l)
+		if err != nil {
+			return Star{}, fmt.Errorf("line %d: %v", r.n, err)
+		}
+		return s, nil
+	}
+	if err := r.s.Err(); err != nil {
+		return Star{}, err
+	}
+	return Star{}, io.EOF
+}
+
+func parseEstartab(l string) (Star, error) {
+	if len(l) < 67 {
+		return Star{}, errors.New("short star record")
+	}
+	field := func(i, j int) string {
+		return strings.TrimSpace(l[i:j])
+	}
+	var f [8]float64
+	for i, c := range [][2]int{{18, 20}, {21, 23}, {24, 30}, {31, 37}, {38, 41}, {42, 44}, {45, 50}, {51, 57}} {
+		var err error
+		if f[i], err = strconv.ParseFloat(field(c[0], c[1]), 64); err != nil {
+			return Star{}, err
+		}
+	}
+	mag, err := strconv.ParseFloat(field(63, 67), 64)
+	if err != nil {
+		return Star{}, err
+	}
+	// Mean place in hours and degrees, and proper motion in seconds of time
+	// and arc per year.
+	alpha := f[0] + f[1]/60 + f[2]/3600
+	delta := math.Abs(f[4]) + f[5]/60 + f[6]/3600
+	if strings.HasPrefix(field(38, 41), "-") {
+		delta = -delta
+	}
+	da, dd := f[3], f[7]
+	// Remove E-terms of aberration except when finding catalog mean places.
+	alpha += (0.341 / (3600 * 15)) * math.Sin((alpha+11.26)*15*radian) / math.Cos(delta*radian)
+	delta += (0.341/3600)*math.Cos((alpha+11.26)*15*radian)*math.Sin(delta*radian) - (0.029/3600)*math.Cos(delta*radian)
+	// Carry the place to J2000.
+	epoch := (1950-1900)*365.2422 + 0.313
+	tau := (36525 - epoch) / 365.2422
+	a, d := precess((alpha+tau*da/3600)*15*radian, (delta+tau*dd/3600)*radian, epoch, 36525)
+	return Star{
+		Name:  "SAO " + l[:6],
+		RA:    math.Mod(a/radian+360, 360),
+		Dec:   d / radian,
+		PMRA:  da * 15000 * math.Cos(d),
+		PMDec: dd * 1000,
+		Mag:   mag,
+	}, nil
+}
+
+// A csvReader reads a star catalog in CSV format with a header row naming
+// its columns.
+type csvReader struct {
+	r     *csv.Reader
+	col   map[string]int
+	parse func(rec []string) (Star, error)
+}
+
+func newCSVReader(r io.Reader) *csvReader {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	return &csvReader{r: cr}
+}
+
+// header reads the header row.
+func (r *csvReader) header() error {
+	rec, err := r.r.Read()
+	if err == io.EOF {
+		return errors.New("missing header")
+	}
+	if err != nil {
+		return err
+	}
+	r.col = make(map[string]int)
+	for i, s := range rec {
+		r.col[strings.ToLower(strings.TrimSpace(s))] = i
+	}
+	return nil
+}
+
+// has reports whether the catalog has all the named columns.
+func (r *csvReader) has(names ...string) bool {
+	for _, s := range names {
+		if _, ok := r.col[strings.ToLower(s)]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *csvReader) Read() (Star, error) {
+	rec, err := r.r.Read()
+	if err != nil {
+		return Star{}, err
+	}
+	line, _ := r.r.FieldPos(0)
+	s, err := r.parse(rec)
+	if err != nil {
+		return Star{}, fmt.Errorf("line %d: %v", line, err)
+	}
+	return s, nil
+}
+
+// field returns the trimmed value of the named column of rec.
+func (r *csvReader) field(rec []string, name string) string {
+	i, ok := r.col[strings.ToLower(name)]
+	if !ok || i >= len(rec) {
+		return ""
+	}
+	return strings.TrimSpace(rec[i])
+}
+
+// float returns the value of the named column of rec. An empty value is an
+// error unless the column is optional, in which case it is zero.
+func (r *csvReader) float(rec []string, name string, optional bool) (float64, error) {
+	s := r.field(rec, name)
+	if s == "" {
+		if optional {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("missing %s", name)
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("bad %s %q", name, s)
+	}
+	return v, nil
+}
+
+// star returns the star with the given place (degrees) and proper motion
+// (mas/yr) at epoch (Julian year), carried to J2000.
+func star(name string, ra, dec, pmra, pmdec, mag, epoch float64) Star {
+	tau := (2000 - epoch) / 3.6e6
+	dec += pmdec * tau
+	ra += pmra * tau / math.Cos(dec*radian)
+	return Star{Name: name, RA: math.Mod(ra+360, 360), Dec: dec, PMRA: pmra, PMDec: pmdec, Mag: mag}
+}
+
+// NewHipparcosReader returns a StarReader that reads the Hipparcos or
+// Tycho-2 catalog in CSV format, with the column names of the CDS:
+//
+//   - HIP, or TYC1, TYC2, and TYC3, which name the star;
+//   - RAICRS and DEICRS, or RAdeg and DEdeg, the position (degrees) at
+//     J1991.25, or RAmdeg and DEmdeg, the mean position of Tycho-2 at J2000;
+//   - pmRA and pmDE, the proper motion (mas/yr), which may be empty;
+//   - Vmag or VTmag, the magnitude.
+func NewHipparcosReader(r io.Reader) (StarReader, error) {
+	cr := newCSVReader(r)
+	if err := cr.header(); err != nil {
+		return nil, err
+	}
+	var ra, dec, mag string
+	epoch := 1991.25
+	switch {
+	case cr.has("RAICRS", "DEICRS"):
+		ra, dec = "RAICRS", "DEICRS"
+	case cr.has("RAdeg", "DEdeg"):
+		ra, dec = "RAdeg", "DEdeg"
+	case cr.has("RAmdeg", "DEmdeg"):
+		ra, dec = "RAmdeg", "DEmdeg"
+		epoch = 2000
+	default:
+		return nil, errors.New("no position columns")
+	}
+	switch {
+	case cr.has("Vmag"):
+		mag = "Vmag"
+	case cr.has("VTmag"):
+		mag = "VTmag"
+	default:
+		return nil, errors.New("no magnitude column")
+	}
+	tycho := cr.has("TYC1", "TYC2", "TYC3")
+	if !tycho && !cr.has("HIP") {
+		return nil, errors.New("no HIP or TYC columns")
+	}
+	cr.parse = func(rec []string) (Star, error) {
+		var f [5]float64
+		for i, c := range []struct {
+			name     string
+			optional bool
+		}{{ra, false}, {dec, false}, {"pmRA", true}, {"pmDE", true}, {mag, false}} {
+			var err error
+			if f[i], err = cr.float(rec, c.name, c.optional); err != nil {
+				return Star{}, err
+			}
+		}
+		name := "HIP " + cr.field(rec, "HIP")
+		if tycho {
+			name = fmt.Sprintf("TYC %s-%s-%s", cr.field(rec, "TYC1"), cr.field(rec, "TYC2"), cr.field(rec, "TYC3"))
+		}
+		return star(name, f[0], f[1], f[2], f[3], f[4], epoch), nil
+	}
+	return cr, nil
+}
+
+// NewGaiaReader returns a StarReader that reads a catalog in CSV format
+// with the column names of the Gaia archive: source_id; ra and dec, the
+// position (degrees) at ref_epoch, which is J2016.0 if the column is
+// missing; pmra and pmdec, the proper motion (mas/yr), which may be empty;
+// and phot_g_mean_mag, the magnitude in the G band.
+func NewGaiaReader(r io.Reader) (StarReader, error) {
+	cr := newCSVReader(r)
+	if err := cr.header(); err != nil {
+		return nil, err
+	}
+	if !cr.has("source_id", "ra", "dec", "phot_g_mean_mag") {
+		return nil, errors.New("missing source_id, ra, dec, or phot_g_mean_mag column")
+	}
+	cr.parse = func(rec []string) (Star, error) {
+		var f [6]float64
+		for i, c := range []struct {
+			name     string
+			optional bool
+		}{{"ra", false}, {"dec", false}, {"pmra", true}, {"pmdec", true}, {"phot_g_mean_mag", false}, {"ref_epoch", true}} {
+			var err error
+			if f[i], err = cr.float(rec, c.name, c.optional); err != nil {
+				return Star{}, err
+			}
+		}
+		if f[5] == 0 {
+			f[5] = 2016
+		}
+		return star("Gaia "+cr.field(rec, "source_id"), f[0], f[1], f[2], f[3], f[4], f[5]), nil
+	}
+	return cr, nil
+}
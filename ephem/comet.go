@@ -0,0 +1,97 @@
+// Copyright 2024 Matthew P. Dargan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ephem
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A Comet holds the orbital elements of a comet. Angles are referred to the
+// ecliptic and equinox of J2000.
+type Comet struct {
+	Designation string    // such as "1P" or "C/2023 A3"
+	Name        string    // such as "1P/Halley"
+	Perihelion  time.Time // time of perihelion passage
+	Q           float64   // perihelion distance (au)
+	E           float64   // eccentricity
+	Peri        float64   // argument of perihelion (degrees)
+	Node        float64   // longitude of the ascending node (degrees)
+	Incl        float64   // inclination (degrees)
+	H, K        float64   // absolute magnitude and slope parameter
+}
+
+// ReadComets reads comet elements in the one-line format of the Minor
+// Planet Center, as in its CometEls.txt file. Blank lines are skipped.
+func ReadComets(r io.Reader) ([]Comet, error) {
+	var cs []Comet
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		l := s.Text()
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+		cm, err := parseComet(l)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", n, err)
+		}
+		cs = append(cs, cm)
+	}
+	return cs, s.Err()
+}
+
+func parseComet(l string) (Comet, error) {
+	if len(l) < 103 {
+		return Comet{}, errors.New("short comet record")
+	}
+	field := func(i, j int) string {
+		return strings.TrimSpace(l[i:j])
+	}
+	var cm Comet
+	y, err := strconv.Atoi(field(14, 18))
+	if err != nil {
+		return Comet{}, err
+	}
+	m, err := strconv.Atoi(field(19, 21))
+	if err != nil {
+		return Comet{}, err
+	}
+	d, err := strconv.ParseFloat(field(22, 29), 64)
+	if err != nil {
+		return Comet{}, err
+	}
+	cm.Perihelion = time.Date(y, time.Month(m), 0, 0, 0, 0, 0, time.UTC).Add(time.Duration(d * secondsPerDay * float64(time.Second)))
+	for _, f := range []struct {
+		v    *float64
+		i, j int
+	}{
+		{&cm.Q, 30, 39},
+		{&cm.E, 41, 49},
+		{&cm.Peri, 51, 59},
+		{&cm.Node, 61, 69},
+		{&cm.Incl, 71, 79},
+	} {
+		if *f.v, err = strconv.ParseFloat(field(f.i, f.j), 64); err != nil {
+			return Comet{}, err
+		}
+	}
+	// The magnitude parameters are often missing.
+	cm.H, _ = strconv.ParseFloat(field(90, 95), 64)
+	cm.K, _ = strconv.ParseFloat(field(96, 100), 64)
+	cm.Name = field(102, min(len(l), 158))
+	cm.Designation = cm.Name
+	if i := strings.Index(cm.Designation, " ("); i >= 0 {
+		cm.Designation = cm.Designation[:i]
+	} else if i := strings.Index(cm.Designation, "/"); i > 0 && cm.Designation[0] >= '0' && cm.Designation[0] <= '9' {
+		// A numbered periodic comet, such as 1P/Halley.
+		cm.Designation = cm.Designation[:i]
+	}
+	return cm, nil
+}
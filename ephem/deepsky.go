@@ -0,0 +1,195 @@
+// Copyright 2024 Matthew P. Dargan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ephem
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"time"
+)
+
+// A DeepSkyObject is a star cluster, nebula, or galaxy of the Messier or
+// Caldwell catalog.
+type DeepSkyObject struct {
+	Name    string  // such as "M31" or "C14"
+	NGC     string  // NGC or IC designation, if any, such as "NGC 224"
+	Common  string  // common name, if any, such as "Andromeda Galaxy"
+	Type    string  // such as "galaxy" or "globular cluster"
+	RA, Dec float64 // right ascension and declination at J2000 (degrees)
+	Mag     float64 // visual magnitude, or zero if it has none
+	Size    float64 // greatest apparent dimension (minutes of arc)
+}
+
+// DeepSky returns the objects of the Messier catalog followed by those of
+// the Caldwell catalog.
+func DeepSky() []DeepSkyObject {
+	return slices.Clone(deepSky)
+}
+
+// A Window is the time during which a deep-sky object is best placed for
+// observing.
+type Window struct {
+	Object     DeepSkyObject
+	Start, End time.Time
+	Transit    time.Time // upper transit during the window, or the zero Time if there is none
+	El         float64   // greatest elevation during the window (degrees), at the transit if there is one
+}
+
+// WindowOptions configure the search made by [Observer.Windows]. A nil
+// *WindowOptions is equivalent to a zero WindowOptions.
+type WindowOptions struct {
+	// MinEl is the least elevation, in degrees, at which an object is
+	// observed. If zero, it is 30.
+	MinEl float64
+
+	// MoonDist is the least distance, in degrees, from the moon while it is
+	// above the horizon. If zero, it is 30.
+	MoonDist float64
+}
+
+// windowStep is the interval, in steps, at which the conditions for
+// observing are sampled: 10 minutes.
+const windowStep = 10 / (24 * 60 * stepSize)
+
+// siderealDay is the length of the sidereal day in steps.
+const siderealDay = 0.99726957 / stepSize
+
+// Windows returns the best observing windows during the day starting at t
+// of the objects reported by [DeepSky], sorted by the time of the upper
+// transit nearest the middle of each window, which may fall outside it. An
+// object is well placed while it is above the least elevation, the sun is
+// more than 18° below the horizon, and the moon is below the horizon or far
+// enough away. An object's window is the longest such time; objects that
+// have none are left out.
+func (o *Observer) Windows(t time.Time, opts *WindowOptions) []Window {
+	if opts == nil {
+		opts = new(WindowOptions)
+	}
+	minEl := opts.MinEl
+	if minEl == 0 {
+		minEl = 30
+	}
+	moonDist := opts.MoonDist
+	if moonDist == 0 {
+		moonDist = 30
+	}
+	c := newState(o)
+	c.setup(o, t)
+	c.tol = 1 / (secondsPerDay * stepSize)
+	// margin is positive while p is well placed.
+	margin := func(sun, moon, p Coord) float64 {
+		m := max(-moon.El, dist(moon, p)/3600-moonDist)
+		return min(-18-sun.El, p.El-minEl, m)
+	}
+	at := func(s *DeepSkyObject, x float64) (sun, moon, p Coord) {
+		c.seTime(c.day + x*stepSize)
+		c.fSun()
+		c.obj(&sun)
+		c.moon()
+		c.obj(&moon)
+		c.deepSkyPlace(s)
+		c.obj(&p)
+		return sun, moon, p
+	}
+	n := int(math.Round(numPoints / windowStep))
+	m := make([][]float64, len(deepSky))
+	for j := range m {
+		m[j] = make([]float64, n+1)
+	}
+	for i := range n + 1 {
+		var sun, moon, p Coord
+		c.seTime(c.day + float64(i)*windowStep*stepSize)
+		c.fSun()
+		c.obj(&sun)
+		if -18-sun.El <= 0 {
+			for j := range m {
+				m[j][i] = -18 - sun.El
+			}
+			continue
+		}
+		c.moon()
+		c.obj(&moon)
+		for j := range deepSky {
+			c.deepSkyPlace(&deepSky[j])
+			c.obj(&p)
+			m[j][i] = margin(sun, moon, p)
+		}
+	}
+	// Each window is kept with its transit, in steps, by which they are
+	// sorted.
+	type window struct {
+		w  Window
+		tr float64
+	}
+	var ws []window
+	for j := range deepSky {
+		s := &deepSky[j]
+		f := func(x float64) float64 {
+			return margin(at(s, x))
+		}
+		// Find the longest run of samples during which s is well placed.
+		first, last := -1, -1
+		for i := 0; i <= n; {
+			if m[j][i] <= 0 {
+				i++
+				continue
+			}
+			k := i
+			for k < n && m[j][k+1] > 0 {
+				k++
+			}
+			if first < 0 || k-i > last-first {
+				first, last = i, k
+			}
+			i = k + 1
+		}
+		if first < 0 {
+			continue
+		}
+		a := float64(first) * windowStep
+		if first > 0 {
+			a = c.root(f, a-windowStep, a, m[j][first-1], m[j][first])
+		}
+		b := float64(last) * windowStep
+		if last < n {
+			b = c.root(f, b, b+windowStep, m[j][last], m[j][last+1])
+		}
+		mid := (a + b) / 2
+		_, _, p := at(s, mid)
+		tr := mid - math.Remainder(p.HA, twoPi)/twoPi*siderealDay
+		w := Window{
+			Object: *s,
+			Start:  julianToTime(c.day + a*stepSize),
+			End:    julianToTime(c.day + b*stepSize),
+		}
+		if tr >= a && tr <= b {
+			_, _, p = at(s, tr)
+			w.Transit = julianToTime(c.day + tr*stepSize)
+			w.El = p.El
+		} else {
+			// The elevation changes monotonically between transits, so it
+			// is greatest at one end of the window.
+			_, _, pa := at(s, a)
+			_, _, pb := at(s, b)
+			w.El = max(pa.El, pb.El)
+		}
+		ws = append(ws, window{w, tr})
+	}
+	slices.SortStableFunc(ws, func(w1, w2 window) int {
+		return cmp.Compare(w1.tr, w2.tr)
+	})
+	sorted := make([]Window, len(ws))
+	for i := range ws {
+		sorted[i] = ws[i].w
+	}
+	return sorted
+}
+
+// deepSkyPlace sets the apparent place at c.eday of s.
+func (c *state) deepSkyPlace(s *DeepSkyObject) {
+	c.place(s.RA/15, s.Dec, 0, 0, 1e9, 36525) // J2000
+	c.mag = s.Mag
+}
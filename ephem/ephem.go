@@ -0,0 +1,603 @@
+// Copyright 2024 Matthew P. Dargan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package ephem computes the positions of the sun, moon, and planets and
+// searches for celestial events such as rises, sets, eclipses, and
+// occultations.
+//
+// All computations are made for an [Observer] on the surface of the earth.
+// Angles that describe a position on the celestial sphere are in radians;
+// angles that describe a position in the sky of the observer are in degrees.
+package ephem
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+)
+
+const (
+	secondsPerDay = 24 * 60 * 60
+	twoPi         = 2 * math.Pi
+	radian        = math.Pi / 180
+	radsec        = radian / 3600
+	converge      = 1e-14
+	metersToFeet  = 3.28084
+	auKm          = 149597870.7
+	iVal          = 1.0
+	numPoints     = 12
+	stepSize      = iVal / numPoints
+)
+
+// A Flag describes how an [Event] is reported.
+type Flag int
+
+const (
+	Dark   Flag = 1 << iota // visible only when the sun is well below the horizon
+	Signif                  // significant; reported before other events
+	Timed                   // the event happens at a particular time
+	Light                   // visible only when the sun is above the horizon
+)
+
+// A Kind identifies the type of an [Event].
+type Kind string
+
+const (
+	Rise                Kind = "rise"
+	Set                 Kind = "set"
+	Transit             Kind = "transit"       // upper culmination
+	LowerTransit        Kind = "lower-transit" // lower culmination of a circumpolar object
+	Season              Kind = "season"        // equinox or solstice
+	MeteorShower        Kind = "meteor-shower"
+	TwilightStart       Kind = "twilight-start" // astronomical twilight
+	TwilightEnd         Kind = "twilight-end"
+	NauticalStart       Kind = "nautical-twilight-start"
+	NauticalEnd         Kind = "nautical-twilight-end"
+	CivilStart          Kind = "civil-twilight-start"
+	CivilEnd            Kind = "civil-twilight-end"
+	GoldenHourStart     Kind = "golden-hour-start"
+	GoldenHourEnd       Kind = "golden-hour-end"
+	BlueHourStart       Kind = "blue-hour-start"
+	BlueHourEnd         Kind = "blue-hour-end"
+	MoonPhase           Kind = "moon-phase"
+	Perigee             Kind = "perigee"
+	Apogee              Kind = "apogee"
+	Supermoon           Kind = "supermoon"  // full moon near perigee
+	Micromoon           Kind = "micromoon"  // full moon near apogee
+	Elongation          Kind = "elongation" // greatest elongation of Mercury or Venus
+	Opposition          Kind = "opposition"
+	SuperiorConjunction Kind = "superior-conjunction" // planet beyond the sun
+	InferiorConjunction Kind = "inferior-conjunction" // Mercury or Venus between the earth and the sun
+	Quadrature          Kind = "quadrature"
+	StationRetrograde   Kind = "station-retrograde" // planet turns westward
+	StationDirect       Kind = "station-direct"     // planet turns eastward
+	Conjunction         Kind = "conjunction"
+	PartialEclipseStart Kind = "partial-eclipse-start"
+	PartialEclipseEnd   Kind = "partial-eclipse-end"
+	TotalEclipseStart   Kind = "total-eclipse-start"
+	TotalEclipseEnd     Kind = "total-eclipse-end"
+	OccultationStart    Kind = "occultation-start"
+	OccultationEnd      Kind = "occultation-end"
+	SolarTransitStart   Kind = "solar-transit-start" // Mercury or Venus crossing the sun
+	SolarTransitEnd     Kind = "solar-transit-end"
+
+	// Phenomena of the Galilean satellites of Jupiter.
+	JovianTransitStart     Kind = "jovian-transit-start" // satellite crosses the disk
+	JovianTransitEnd       Kind = "jovian-transit-end"
+	JovianShadowStart      Kind = "jovian-shadow-start" // shadow of a satellite crosses the disk
+	JovianShadowEnd        Kind = "jovian-shadow-end"
+	JovianOccultationStart Kind = "jovian-occultation-start" // satellite hidden by the disk
+	JovianOccultationEnd   Kind = "jovian-occultation-end"
+	JovianEclipseStart     Kind = "jovian-eclipse-start" // satellite in the shadow of Jupiter
+	JovianEclipseEnd       Kind = "jovian-eclipse-end"
+)
+
+// A Twilight is a set of the kinds of twilight reported by
+// [Observer.Events].
+type Twilight int
+
+const (
+	Astronomical Twilight = 1 << iota // sun at -18°
+	Nautical                          // sun at -12°
+	Civil                             // sun at -6°
+	GoldenHour                        // sun between +6° and -4°
+	BlueHour                          // sun between -4° and -6°
+)
+
+// An Observer is a point of observation on the surface of the earth.
+// Its methods may be called simultaneously from multiple goroutines.
+type Observer struct {
+	Lat       float64    // north latitude (degrees)
+	WLong     float64    // west longitude (degrees)
+	Elev      float64    // elevation (meters)
+	DeltaT    float64    // ephemeris minus universal time (seconds); 0 means use an empirical formula
+	Comets    []Comet    // comets to report; nil means the last interesting comet
+	Asteroids []Asteroid // asteroids to report
+	Showers   []Shower   // meteor showers besides those of [Showers], each replacing any with its code
+}
+
+// A Coord is the apparent position of an object as seen by an [Observer].
+type Coord struct {
+	RA   float64 // topocentric right ascension (radians)
+	Decl float64 // topocentric declination (radians)
+	Semi float64 // semidiameter (arc seconds)
+	Az   float64 // azimuth (degrees)
+	El   float64 // elevation (degrees)
+	Mag  float64 // apparent visual magnitude
+	Dist float64 // geocentric distance (au)
+	HA   float64 // local hour angle (radians)
+
+	Lon   float64 // apparent geocentric ecliptic longitude (radians)
+	Elong float64 // elongation from the sun (degrees)
+	Phase float64 // phase angle, at the object between the sun and the earth (degrees)
+	Illum float64 // illuminated fraction of the disk
+	Limb  float64 // position angle of the midpoint of the bright limb, east of north (degrees)
+}
+
+// A Body names an object whose position can be computed.
+type Body struct {
+	Name     string // short name, such as "mars"
+	FullName string // name used in reports, such as "Mars"
+}
+
+// An Event is a celestial event found by [Observer.Events].
+type Event struct {
+	Kind   Kind
+	Bodies []string // short names of the bodies involved
+	Text   string   // description, such as "Mars rises"
+	Time   time.Time
+	Flag   Flag
+}
+
+type obj2 struct {
+	name, fname string
+	f           func(*state)
+	outer       bool // a planet beyond the orbit of the earth
+	point       [numPoints + 2]Coord
+}
+
+type obj3 struct {
+	t1, e1, t2, e2, t3, e3, t4, e4, t5, e5 float64
+}
+
+type moonTab struct {
+	f float64
+	c [4]int
+}
+
+type evt struct {
+	kind   Kind
+	bodies []string
+	s      string
+	tim    float64
+	flag   Flag
+}
+
+type occt struct {
+	act, del0, del1, del2 Coord
+}
+
+// A state holds the intermediate results of a computation. The body
+// functions communicate through its fields, so a state must not be shared
+// between goroutines; each call into the package makes its own.
+type state struct {
+	wlong, awlong, nlat, elev,
+	obliq, phi, eps, tobliq,
+	day, eday, capt, capt2, capt3, gst,
+	ΔT, erad, glat,
+	xms, yms, zms, xdot, ydot, zdot,
+	motion, lambda, beta, rad, mag, semi,
+	alpha, delta, hp,
+	ra, semi2, lha, decl2, lmb2, az, el,
+	meday, seday, mhp, salph, sdelt, srad float64
+	k1, k2, k3, k4, mnom, msun, noded, dmoon float64
+	ringB, ringBSun, ringP                   float64
+	tol                                      float64
+	twilight                                 Twilight
+	supermoon                                float64
+	jupiter                                  bool
+	starConj                                 bool
+	showers                                  []Shower
+	occultMode                               bool
+	events                                   []evt
+	oSun, oMoon, oShad, oMerc, oVenus        obj2
+	objs                                     []*obj2
+	oStar                                    obj2
+	occ                                      obj3
+	occ1, occ2                               occt
+}
+
+// newState returns a state for computations made by o, which include its
+// comets, or the last interesting comet if it has none, its asteroids, and
+// its meteor showers.
+func newState(o *Observer) *state {
+	c := new(state)
+	c.oSun = obj2{name: "sun", fname: "The sun", f: (*state).fSun}
+	c.oMoon = obj2{name: "moon", fname: "The moon", f: (*state).moon}
+	c.oShad = obj2{name: "shadow", fname: "The shadow", f: (*state).shad}
+	c.oMerc = obj2{name: "mercury", fname: "Mercury", f: (*state).merc}
+	c.oVenus = obj2{name: "venus", fname: "Venus", f: (*state).venus}
+	c.objs = []*obj2{
+		&c.oSun, &c.oMoon, &c.oShad, &c.oMerc, &c.oVenus,
+		{name: "mars", fname: "Mars", outer: true, f: (*state).mars},
+		{name: "jupiter", fname: "Jupiter", outer: true, f: (*state).jup},
+		{name: "saturn", fname: "Saturn", outer: true, f: (*state).sat},
+		{name: "uranus", fname: "Uranus", outer: true, f: (*state).uran},
+		{name: "neptune", fname: "Neptune", outer: true, f: (*state).nept},
+		{name: "pluto", fname: "Pluto", outer: true, f: (*state).plut},
+	}
+	if o.Comets == nil {
+		c.objs = append(c.objs, &obj2{name: "comet", fname: "Comet", f: func(c *state) { c.comet(&comet153P) }})
+	}
+	for _, cm := range o.Comets {
+		c.objs = append(c.objs, &obj2{name: cm.Designation, fname: cm.Name, f: func(c *state) { c.comet(&cm) }})
+	}
+	for _, a := range o.Asteroids {
+		c.objs = append(c.objs, &obj2{name: a.Designation, fname: a.Name, f: func(c *state) { c.asteroid(&a) }})
+	}
+	c.oStar = obj2{name: "Star", f: (*state).star}
+	c.showers = o.showers()
+	return c
+}
+
+// find returns the body of c matched by name against both its short and full
+// names, or nil.
+func (c *state) find(name string) *obj2 {
+	i := slices.IndexFunc(c.objs, func(p *obj2) bool {
+		return name == p.name || name == p.fname
+	})
+	if i < 0 {
+		return nil
+	}
+	return c.objs[i]
+}
+
+// setup prepares c for computations made by o at t.
+func (c *state) setup(o *Observer, t time.Time) {
+	c.day = timeToJulian(t)
+	c.ΔT = o.DeltaT
+	if c.ΔT == 0 {
+		c.ΔT = deltaT(c.day)
+	}
+	c.nlat = o.Lat * radian
+	c.awlong = o.WLong * radian
+	c.elev = o.Elev * metersToFeet
+	c.glat = c.nlat - (692.74*radsec)*math.Sin(2*c.nlat) + (1.16*radsec)*math.Sin(4*c.nlat)
+	c.erad = 0.99832707e0 + 0.00167644e0*math.Cos(2*c.nlat) - 0.352e-5*math.Cos(4*c.nlat) + 0.001e-5*math.Cos(6*c.nlat) + 0.1568e-6*c.elev
+	// Force the shadow to recompute the sun and moon.
+	c.seday = math.NaN()
+	c.meday = math.NaN()
+}
+
+// Bodies returns the bodies known to the package in the order they are
+// reported.
+func Bodies() []Body {
+	return new(Observer).Bodies()
+}
+
+// Bodies returns the bodies whose positions o can compute, including its
+// comets, in the order they are reported.
+func (o *Observer) Bodies() []Body {
+	objs := newState(o).objs
+	b := make([]Body, len(objs))
+	for i, o := range objs {
+		b[i] = Body{Name: o.name, FullName: o.fname}
+	}
+	return b
+}
+
+// Position returns the position of the named body at time t. The body is
+// matched against both the short and full names reported by [Bodies] and,
+// ignoring case, against the names and designations of the stars reported
+// by [Stars].
+func (o *Observer) Position(body string, t time.Time) (Coord, error) {
+	c := newState(o)
+	var f func(*state)
+	if b := c.find(body); b != nil {
+		f = b.f
+	} else if s, ok := findStar(body); ok {
+		f = func(c *state) { c.catalogPlace(s) }
+	} else {
+		return Coord{}, fmt.Errorf("unknown body %q", body)
+	}
+	c.setup(o, t)
+	c.seTime(c.day)
+	f(c)
+	var p Coord
+	c.obj(&p)
+	return p, nil
+}
+
+// A Phase is a principal phase of the moon.
+type Phase struct {
+	Quarter int    // 0 for new moon, 1 for first quarter, 2 for full moon, 3 for last quarter
+	Name    string // such as "Full moon"
+	Time    time.Time
+}
+
+// Phases returns the principal phases of the moon from t to end, the
+// instants at which its elongation in longitude from the sun is a multiple
+// of 90°.
+func (o *Observer) Phases(t, end time.Time) []Phase {
+	c := newState(o)
+	c.setup(o, t)
+	c.tol = 1 / (secondsPerDay * stepSize)
+	// The moon gains about 12° a day on the sun, so no quarter is passed
+	// over in a day.
+	n := end.Sub(t).Hours() / 24 / stepSize
+	var ps []Phase
+	q1 := quarter(c.moonElong(0))
+	for x := 0.; x < n; x += numPoints {
+		b := min(x+numPoints, n)
+		q2 := quarter(c.moonElong(b))
+		if q2 != q1 {
+			ps = append(ps, Phase{Quarter: q2, Name: phaseNames[q2], Time: julianToTime(c.day + c.phaseTime(q2, x, b)*stepSize)})
+		}
+		q1 = q2
+	}
+	return ps
+}
+
+// A Station is a point in the retrograde loop of a planet.
+type Station struct {
+	Time          time.Time
+	Lon           float64 // apparent geocentric ecliptic longitude (degrees)
+	Constellation string  // zodiacal constellation at Lon
+}
+
+// A Loop is the retrograde loop of a planet: it turns westward at Start,
+// is at opposition to the sun, and turns eastward again at End.
+type Loop struct {
+	Start, Opposition, End Station
+}
+
+// Retrograde returns the first retrograde loop of the named planet, one of
+// Mars through Pluto, that starts after t.
+func (o *Observer) Retrograde(body string, t time.Time) (Loop, error) {
+	c := newState(o)
+	p := c.find(body)
+	if p == nil || !p.outer {
+		return Loop{}, fmt.Errorf("no retrograde loop for %q", body)
+	}
+	c.setup(o, t)
+	c.tol = 1 / (secondsPerDay * stepSize)
+	// Retrograde loops recur at the synodic period, at most that of Mars.
+	const days = 800
+	t1 := c.station(p, 0, days*numPoints, true)
+	if t1 < 0 {
+		return Loop{}, fmt.Errorf("no retrograde loop for %q", body)
+	}
+	t2 := c.station(p, t1, t1+days*numPoints, false)
+	if t2 < 0 {
+		return Loop{}, fmt.Errorf("no retrograde loop for %q", body)
+	}
+	to := c.maximum(func(x float64) float64 { return c.elong(p, x) }, t1, t2)
+	st := func(x float64) Station {
+		lon := c.lonAt(p, x)
+		return Station{
+			Time:          julianToTime(c.day + x*stepSize),
+			Lon:           math.Mod(lon/radian+360, 360),
+			Constellation: c.constellation(lon),
+		}
+	}
+	return Loop{Start: st(t1), Opposition: st(to), End: st(t2)}, nil
+}
+
+// SiderealTime returns the local sidereal time at t in radians.
+func (o *Observer) SiderealTime(t time.Time) float64 {
+	c := newState(o)
+	c.setup(o, t)
+	c.seTime(c.day)
+	c.semi = 0
+	c.motion = 0
+	c.rad = 1e9
+	c.lambda = 0
+	c.beta = 0
+	c.helio()
+	c.geo()
+	return c.lha
+}
+
+// Options configure the search made by [Observer.Events]. A nil *Options
+// is equivalent to a zero Options.
+type Options struct {
+	// Stars, if not nil, is read for stars and the search includes their
+	// occultations by the moon.
+	Stars StarReader
+
+	// Occultations, if true and Stars is nil, makes the search include
+	// occultations by the moon of the stars in the embedded catalog.
+	Occultations bool
+
+	// StarConjunctions, if true, makes the search include conjunctions of
+	// the moon and planets with the first-magnitude stars of the embedded
+	// catalog.
+	StarConjunctions bool
+
+	// Tolerance is the precision to which the times of rises, sets, and
+	// other crossings are found. If zero, it is one second.
+	Tolerance time.Duration
+
+	// Twilight is the set of kinds of twilight to report. If zero, it is
+	// Astronomical.
+	Twilight Twilight
+
+	// Jupiter, if true, makes the search include the transits, shadow
+	// transits, occultations, and eclipses of the Galilean satellites.
+	Jupiter bool
+
+	// Supermoon is how close, in kilometers, a full moon must come to the
+	// least distance of the moon at perigee (356400 km) to be reported as a
+	// supermoon, or to its greatest distance at apogee (406700 km) to be
+	// reported as a micromoon. If zero, it is 5000.
+	Supermoon float64
+}
+
+// Events searches for events in the day starting at t. Significant events
+// come first, followed by the rest in time order.
+func (o *Observer) Events(t time.Time, opts *Options) ([]Event, error) {
+	if opts == nil {
+		opts = new(Options)
+	}
+	c := newState(o)
+	c.setup(o, t)
+	c.occultMode = opts.Stars != nil || opts.Occultations
+	c.starConj = opts.StarConjunctions
+	c.tol = opts.Tolerance.Seconds()
+	if c.tol <= 0 {
+		c.tol = 1
+	}
+	c.tol /= secondsPerDay * stepSize
+	c.twilight = opts.Twilight
+	if c.twilight == 0 {
+		c.twilight = Astronomical
+	}
+	c.jupiter = opts.Jupiter
+	c.supermoon = opts.Supermoon
+	if c.supermoon <= 0 {
+		c.supermoon = 5000
+	}
+	d := c.day
+	for i := range c.objs[0].point {
+		c.seTime(d)
+		for _, p := range c.objs {
+			p.f(c)
+			c.obj(&p.point[i])
+		}
+		d += stepSize
+	}
+	if err := c.search(opts.Stars); err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(c.events, func(e1, e2 evt) int {
+		t1, t2 := e1.tim, e2.tim
+		if e1.flag&Signif > 0 {
+			t1 -= 1000
+		}
+		if e2.flag&Signif > 0 {
+			t2 -= 1000
+		}
+		return cmp.Compare(t1, t2)
+	})
+	es := make([]Event, len(c.events))
+	for i, e := range c.events {
+		es[i] = Event{Kind: e.kind, Bodies: e.bodies, Text: e.s, Time: julianToTime(c.day + e.tim*stepSize), Flag: e.flag}
+	}
+	return es, nil
+}
+
+// Dist returns the angular distance between two positions in arc seconds.
+func Dist(c1, c2 Coord) float64 {
+	return dist(c1, c2)
+}
+
+// Julian returns the number of days between 1899 December 31 12:00 UTC and
+// t. It is the Julian date less 2415020.
+func Julian(t time.Time) float64 {
+	return timeToJulian(t)
+}
+
+var t1899 = time.Date(1899, 12, 31, 12, 0, 0, 0, time.UTC)
+
+func timeToJulian(t time.Time) float64 {
+	return (float64(t.Unix()-t1899.Unix()) + float64(t.Nanosecond())/1e9) / secondsPerDay
+}
+
+func julianToTime(jd float64) time.Time {
+	s := jd * secondsPerDay
+	return t1899.Add(time.Duration(s) * time.Second)
+}
+
+func deltaT(jDay float64) float64 {
+	const meanSolarDaySeconds = 0.001704
+	return jDay * meanSolarDaySeconds
+}
+
+func (c *state) seTime(d float64) {
+	c.eday = d + c.ΔT/86400
+	c.wlong = c.awlong + 15*c.ΔT*radsec
+	c.capt = c.eday / 36524.22e0
+	c.capt2 = c.capt * c.capt
+	c.capt3 = c.capt * c.capt2
+	c.nutate()
+	c.eday += 0.1
+	c.sun()
+	c.srad = c.rad
+	xm := c.rad * math.Cos(c.beta) * math.Cos(c.lambda)
+	ym := c.rad * math.Cos(c.beta) * math.Sin(c.lambda)
+	zm := c.rad * math.Sin(c.beta)
+	c.eday -= 0.1
+	c.sun()
+	c.xms = c.rad * math.Cos(c.beta) * math.Cos(c.lambda)
+	c.yms = c.rad * math.Cos(c.beta) * math.Sin(c.lambda)
+	c.zms = c.rad * math.Sin(c.beta)
+	x := 0.057756
+	c.xdot = x * (xm - c.xms)
+	c.ydot = x * (ym - c.yms)
+	c.zdot = x * (zm - c.zms)
+}
+
+func (c *state) nutate() {
+	// Nutation of the equinoxes is a wobble of the pole of the earth's
+	// rotation whose magnitude is about 9 seconds of arc and whose period
+	// is about 18.6 years. It depends upon the pull of the sun and moon on
+	// the equatorial bulge of the earth. phi and eps are the two angles
+	// which specify the true pole with respect to the mean pole. All
+	// coefficients are from Exp. Supp. pp.44-45.
+	mnom := (296.104608 + 13.0649924465*c.eday + 9.192e-3*c.capt2 + 14.38e-6*c.capt3) * radian
+	msun := (358.475833 + 0.9856002669*c.eday - 0.15e-3*c.capt2 - 3.33e-6*c.capt3) * radian
+	c.noded = (11.250889 + 13.229350449*c.eday - 3.211e-3*c.capt2 - 0.33e-6*c.capt3) * radian
+	dmoon := (350.737486 + 12.1907491914*c.eday - 1.436e-3*c.capt2 + 1.89e-6*c.capt3) * radian
+	node := (259.183275 - 0.0529539222*c.eday + 2.078e-3*c.capt2 + 2.22e-6*c.capt3) * radian
+	c.phi = -(17.2327 + 0.01737*c.capt) * math.Sin(node)
+	c.phi += sinAdd(nutf[0], nutc[0], []float64{node, c.noded, dmoon, msun})
+	c.eps = cosAdd(nutf[1], nutc[1], []float64{node, c.noded, dmoon, msun})
+	dphi := sinAdd(nutf[2], nutc[2], []float64{node, c.noded, mnom, dmoon})
+	deps := cosAdd(nutf[3], nutc[3], []float64{node, c.noded, mnom})
+	c.phi = (c.phi + dphi) * radsec
+	c.eps = (c.eps + deps) * radsec
+	c.obliq = (23.452294 - 0.0130125*c.capt - 1.64e-6*c.capt2 + 0.503e-6*c.capt3) * radian
+	c.tobliq = c.obliq + c.eps
+	c.gst = 99.690983 + 360.9856473354*c.eday + 0.000387*c.capt2 - 180
+	c.gst = math.Mod(c.gst, 360)
+	if c.gst < 0 {
+		c.gst += 360
+	}
+	c.gst *= radian
+	c.gst += c.phi * math.Cos(c.obliq)
+}
+
+func (c *state) obj(o *Coord) {
+	// The horizontal parallax of an object at 1 au is 8.794 seconds of arc.
+	d := 8.794 * radsec / math.Sin(c.hp)
+	*o = Coord{RA: c.ra, Decl: c.decl2, Semi: c.semi2, Az: c.az, El: c.el, Mag: c.mag, Dist: d, HA: c.lha, Lon: c.lmb2}
+	if c.rad == 0 {
+		// The sun.
+		return
+	}
+	// The geocentric equatorial places of the sun and the object.
+	sx := c.xms
+	sy := c.yms*math.Cos(c.obliq) - c.zms*math.Sin(c.obliq)
+	sz := c.yms*math.Sin(c.obliq) + c.zms*math.Cos(c.obliq)
+	r := math.Sqrt(sx*sx + sy*sy + sz*sz)
+	salph, sdelt := math.Atan2(sy, sx), math.Asin(sz/r)
+	px := d * math.Cos(c.delta) * math.Cos(c.alpha)
+	py := d * math.Cos(c.delta) * math.Sin(c.alpha)
+	pz := d * math.Sin(c.delta)
+	ce := (sx*px + sy*py + sz*pz) / (r * d)
+	o.Elong = math.Acos(max(-1, min(1, ce))) / radian
+	ci := (px*px + py*py + pz*pz - sx*px - sy*py - sz*pz) / (d * math.Sqrt((sx-px)*(sx-px)+(sy-py)*(sy-py)+(sz-pz)*(sz-pz)))
+	o.Phase = math.Acos(max(-1, min(1, ci))) / radian
+	o.Illum = (1 + ci) / 2
+	chi := math.Atan2(math.Cos(sdelt)*math.Sin(salph-c.alpha), math.Sin(sdelt)*math.Cos(c.delta)-math.Cos(sdelt)*math.Sin(c.delta)*math.Cos(salph-c.alpha))
+	o.Limb = math.Mod(chi/radian+360, 360)
+}
+
+func dist(o1, o2 Coord) float64 {
+	d := math.Sin(o1.Decl)*math.Sin(o2.Decl) +
+		math.Cos(o1.Decl)*math.Cos(o2.Decl)*math.Cos(o1.RA-o2.RA)
+	return math.Abs(math.Atan2(pyth(d), d)) / radsec
+}
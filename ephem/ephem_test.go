@@ -0,0 +1,113 @@
+// Copyright 2024 Matthew P. Dargan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ephem
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestConcurrent calls the methods of shared Observers from many goroutines
+// at once and checks that each returns what it returns when called alone.
+// Run it with -race.
+func TestConcurrent(t *testing.T) {
+	obs := []*Observer{
+		{Lat: 40.78, WLong: 73.97},              // New York
+		{Lat: -33.86, WLong: -151.21, Elev: 58}, // Sydney
+	}
+	times := []time.Time{
+		time.Date(2024, 4, 8, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 8, 12, 12, 0, 0, 0, time.UTC),
+	}
+	type result struct {
+		events []Event
+		pos    []Coord
+		lst    float64
+		loop   Loop
+		jovian []Event
+		moons  []JovianMoon
+		phases []Phase
+	}
+	run := func(o *Observer, tm time.Time) (result, error) {
+		var r result
+		var err error
+		if r.events, err = o.Events(tm, nil); err != nil {
+			return r, err
+		}
+		for _, b := range Bodies() {
+			p, err := o.Position(b.Name, tm)
+			if err != nil {
+				return r, err
+			}
+			r.pos = append(r.pos, p)
+		}
+		r.lst = o.SiderealTime(tm)
+		if r.loop, err = o.Retrograde("mars", tm); err != nil {
+			return r, err
+		}
+		if r.jovian, err = o.Events(tm, &Options{Jupiter: true}); err != nil {
+			return r, err
+		}
+		r.moons = o.JovianMoons(tm)
+		r.phases = o.Phases(tm, tm.AddDate(0, 1, 0))
+		return r, nil
+	}
+	want := make([][]result, len(obs))
+	for i, o := range obs {
+		for _, tm := range times {
+			r, err := run(o, tm)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want[i] = append(want[i], r)
+		}
+	}
+	var wg sync.WaitGroup
+	for range 4 {
+		for i, o := range obs {
+			for j, tm := range times {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					r, err := run(o, tm)
+					if err != nil {
+						t.Error(err)
+						return
+					}
+					if !reflect.DeepEqual(r, want[i][j]) {
+						t.Errorf("observer %d at %v: concurrent result differs from serial result", i, tm)
+					}
+				}()
+			}
+		}
+	}
+	wg.Wait()
+}
+
+// TestPhases checks the phases of the moon against the times published by
+// the U.S. Naval Observatory, which are given to the minute.
+func TestPhases(t *testing.T) {
+	want := []struct {
+		quarter int
+		time    time.Time
+	}{
+		{3, utc(2024, 1, 4, 3, 30)},
+		{0, utc(2024, 1, 11, 11, 57)},
+		{1, utc(2024, 1, 18, 3, 53)},
+		{2, utc(2024, 1, 25, 17, 54)},
+	}
+	ps := london.Phases(utc(2024, 1, 1, 0, 0), utc(2024, 2, 1, 0, 0))
+	if len(ps) != len(want) {
+		t.Fatalf("got %d phases, want %d", len(ps), len(want))
+	}
+	for i, p := range ps {
+		w := want[i]
+		if p.Quarter != w.quarter || p.Time.Sub(w.time).Abs() > time.Minute {
+			t.Errorf("%s at %v, want quarter %d at %v", p.Name, p.Time.Format(time.DateTime), w.quarter, w.time.Format(time.DateTime))
+		}
+	}
+}
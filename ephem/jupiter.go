@@ -0,0 +1,189 @@
+// Copyright 2024 Matthew P. Dargan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ephem
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// A JovianMoon is the apparent position of a Galilean satellite relative to
+// Jupiter.
+type JovianMoon struct {
+	Name   string  // "Io", "Europa", "Ganymede", or "Callisto"
+	X, Y   float64 // offset from the center of Jupiter in equatorial radii, positive to the west and north
+	Behind bool    // whether it is farther away than Jupiter
+}
+
+var jovianNames = [4]string{"Io", "Europa", "Ganymede", "Callisto"}
+
+// jupiterFlat is the ratio of the polar to the equatorial radius of Jupiter.
+const jupiterFlat = 0.93513
+
+// A jmoon is the position of a Galilean satellite as seen from the earth
+// (x, y) and from the sun (sx, sy), in equatorial radii of Jupiter. The
+// satellite is in front of Jupiter as seen from the earth if front is
+// true, and as seen from the sun if sfront is true.
+type jmoon struct {
+	x, y, sx, sy  float64
+	front, sfront bool
+}
+
+// galilean returns the positions of the Galilean satellites at d, in
+// ephemeris days since J2000, by the low-accuracy method of Meeus. Times of
+// phenomena found from them are good to a few minutes.
+func galilean(d float64) [4]jmoon {
+	v := (172.74 + 0.00111588*d) * radian
+	m := (357.529 + 0.9856003*d) * radian
+	n := (20.020 + 0.0830853*d + 0.329*math.Sin(v)) * radian
+	j := (66.115 + 0.9025179*d - 0.329*math.Sin(v)) * radian
+	a := (1.915*math.Sin(m) + 0.020*math.Sin(2*m)) * radian
+	b := (5.555*math.Sin(n) + 0.168*math.Sin(2*n)) * radian
+	k := j + a - b
+	// Distances of the earth and Jupiter from the sun and of Jupiter from
+	// the earth (au).
+	sr := 1.00014 - 0.01671*math.Cos(m) - 0.00014*math.Cos(2*m)
+	jr := 5.20872 - 0.25208*math.Cos(n) - 0.00611*math.Cos(2*n)
+	delta := math.Sqrt(jr*jr + sr*sr - 2*jr*sr*math.Cos(k))
+	// Phase angle of the earth as seen from Jupiter.
+	psi := math.Asin(sr / delta * math.Sin(k))
+	lon := (34.35+0.083091*d+0.329*math.Sin(v))*radian + b
+	// Jovicentric latitudes of the sun and the earth.
+	ds := 3.12 * radian * math.Sin(lon+42.8*radian)
+	de := ds - 2.22*radian*math.Sin(psi)*math.Cos(lon+22*radian) - 1.30*radian*(jr-delta)/delta*math.Sin(lon-100.5*radian)
+	// Correct for light time.
+	t := d - delta/173
+	u := [4]float64{
+		163.8069 + 203.4058646*t,
+		358.4140 + 101.2916335*t,
+		5.7176 + 50.2345180*t,
+		224.8092 + 21.4879800*t,
+	}
+	for i := range u {
+		u[i] = u[i]*radian + psi - b
+	}
+	g := (331.18 + 50.310482*t) * radian
+	h := (87.45 + 21.569231*t) * radian
+	u1, u2, u3 := u[0], u[1], u[2]
+	u[0] += 0.473 * radian * math.Sin(2*(u1-u2))
+	u[1] += 1.065 * radian * math.Sin(2*(u2-u3))
+	u[2] += 0.165 * radian * math.Sin(g)
+	u[3] += 0.843 * radian * math.Sin(h)
+	r := [4]float64{
+		5.9057 - 0.0244*math.Cos(2*(u1-u2)),
+		9.3966 - 0.0882*math.Cos(2*(u2-u3)),
+		14.9883 - 0.0216*math.Cos(g),
+		26.3627 - 0.1939*math.Cos(h),
+	}
+	var p [4]jmoon
+	for i := range p {
+		// The angle u is measured from inferior conjunction; seen from
+		// the sun, it is less by the phase angle.
+		us := u[i] - psi
+		p[i] = jmoon{
+			x:      r[i] * math.Sin(u[i]),
+			y:      -r[i] * math.Cos(u[i]) * math.Sin(de),
+			sx:     r[i] * math.Sin(us),
+			sy:     -r[i] * math.Cos(us) * math.Sin(ds),
+			front:  math.Cos(u[i]) > 0,
+			sfront: math.Cos(us) > 0,
+		}
+	}
+	return p
+}
+
+// JovianMoons returns the positions of the Galilean satellites at t.
+func (o *Observer) JovianMoons(t time.Time) []JovianMoon {
+	c := newState(o)
+	c.setup(o, t)
+	c.seTime(c.day)
+	p := galilean(c.eday - 36525)
+	ms := make([]JovianMoon, len(p))
+	for i, m := range p {
+		ms[i] = JovianMoon{Name: jovianNames[i], X: m.x, Y: m.y, Behind: !m.front}
+	}
+	return ms
+}
+
+// jovianStep is the interval, in steps, at which the Galilean satellites
+// are sampled: 10 minutes.
+const jovianStep = 10 / (24 * 60 * stepSize)
+
+// jovian searches for the transits, shadow transits, occultations, and
+// eclipses of the Galilean satellites while Jupiter is above the horizon.
+func (c *state) jovian(jup *obj2) error {
+	// inside returns how far within the disk of Jupiter a point is.
+	inside := func(x, y float64) float64 {
+		return 1 - math.Hypot(x, y/jupiterFlat)
+	}
+	phen := []struct {
+		start, end Kind
+		s          string
+		f          func(m jmoon) float64
+	}{
+		{JovianTransitStart, JovianTransitEnd, "Transit of %s %s", func(m jmoon) float64 {
+			if !m.front {
+				return -1
+			}
+			return inside(m.x, m.y)
+		}},
+		{JovianShadowStart, JovianShadowEnd, "Shadow transit of %s %s", func(m jmoon) float64 {
+			if !m.sfront {
+				return -1
+			}
+			return inside(m.sx, m.sy)
+		}},
+		{JovianOccultationStart, JovianOccultationEnd, "Occultation of %s %s", func(m jmoon) float64 {
+			if m.front {
+				return -1
+			}
+			return inside(m.x, m.y)
+		}},
+		{JovianEclipseStart, JovianEclipseEnd, "Eclipse of %s %s", func(m jmoon) float64 {
+			if m.sfront {
+				return -1
+			}
+			return inside(m.sx, m.sy)
+		}},
+	}
+	at := func(x float64) [4]jmoon {
+		return galilean(c.day + x*stepSize + c.ΔT/secondsPerDay - 36525)
+	}
+	jupEl := func(t float64) float64 {
+		i := min(int(t), numPoints)
+		return jup.point[i].El + (t-float64(i))*(jup.point[i+1].El-jup.point[i].El)
+	}
+	p1 := at(0)
+	for x := 0.; x < numPoints; x += jovianStep {
+		p2 := at(x + jovianStep)
+		for i := range p1 {
+			for _, ph := range phen {
+				f1, f2 := ph.f(p1[i]), ph.f(p2[i])
+				if (f1 > 0) == (f2 > 0) {
+					continue
+				}
+				f := func(x float64) float64 {
+					return ph.f(at(x)[i])
+				}
+				t := c.root(f, x, x+jovianStep, f1, f2)
+				if jupEl(t) < 0 {
+					continue
+				}
+				e := evt{kind: ph.start, bodies: []string{jup.name, strings.ToLower(jovianNames[i])}, s: fmt.Sprintf(ph.s, jovianNames[i], "begins"), tim: t, flag: Dark | Timed}
+				if f1 > 0 {
+					e.kind = ph.end
+					e.s = fmt.Sprintf(ph.s, jovianNames[i], "ends")
+				}
+				if err := c.event(e); err != nil {
+					return err
+				}
+			}
+		}
+		p1 = p2
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 Matthew P. Dargan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ephem
+
+import (
+	"math"
+	"time"
+)
+
+// A LunarDisk is the orientation and illumination of the disk of the moon.
+type LunarDisk struct {
+	L, B        float64 // optical libration in longitude and latitude (degrees)
+	P           float64 // position angle of the northern end of the axis of rotation, east of north (degrees)
+	Colongitude float64 // selenographic colongitude of the sun (degrees)
+	BrightLimb  float64 // position angle of the midpoint of the bright limb, east of north (degrees)
+}
+
+// Terminator returns the selenographic longitudes, in degrees east, of the
+// morning terminator, where the sun is rising, and of the evening
+// terminator, where it is setting.
+func (d LunarDisk) Terminator() (morning, evening float64) {
+	return math.Remainder(-d.Colongitude, 360), math.Remainder(180-d.Colongitude, 360)
+}
+
+// LunarDisk returns the orientation and illumination of the disk of the
+// moon at t.
+func (o *Observer) LunarDisk(t time.Time) LunarDisk {
+	c := newState(o)
+	c.setup(o, t)
+	c.seTime(c.day)
+	c.fSun()
+	return c.lunarDisk()
+}
+
+// lunarInclination is the inclination of the mean lunar equator to the
+// ecliptic.
+const lunarInclination = 1.54242 * radian
+
+// lunarDisk returns the orientation and illumination of the disk of the
+// moon by the method of Meeus, leaving out the physical libration. The
+// apparent longitude of the sun must be in c.lmb2 and its place in c.salph
+// and c.sdelt.
+func (c *state) lunarDisk() LunarDisk {
+	lmb0, salph, sdelt := c.lmb2, c.salph, c.sdelt
+	c.moon()
+	lmb, beta := c.lambda, c.beta
+	// The mean longitude of the ascending node of the lunar orbit and the
+	// mean argument of latitude of the moon.
+	node := (259.183275 - 0.0529539222*c.eday + 2.078e-3*c.capt2 + 2.22e-6*c.capt3) * radian
+	f := c.noded * radian
+	// libration returns the selenographic longitude and latitude of the
+	// point seen at ecliptic longitude l and latitude b, without nutation.
+	libration := func(l, b float64) (float64, float64) {
+		w := l - c.phi - node
+		a := math.Atan2(math.Sin(w)*math.Cos(b)*math.Cos(lunarInclination)-math.Sin(b)*math.Sin(lunarInclination), math.Cos(w)*math.Cos(b))
+		return math.Remainder(a-f, twoPi), math.Asin(-math.Sin(w)*math.Cos(b)*math.Sin(lunarInclination) - math.Sin(b)*math.Cos(lunarInclination))
+	}
+	l, b := libration(lmb, beta)
+	// The position angle of the axis.
+	v := node + c.phi
+	x := math.Sin(lunarInclination) * math.Sin(v)
+	y := math.Sin(lunarInclination)*math.Cos(v)*math.Cos(c.tobliq) - math.Cos(lunarInclination)*math.Sin(c.tobliq)
+	omega := math.Atan2(x, y)
+	p := math.Asin(math.Hypot(x, y) * math.Cos(c.alpha-omega) / math.Cos(b))
+	// The selenographic longitude of the sun, seen from the moon, whose
+	// distance from the earth is a small fraction of the sun's.
+	r := math.Sin(8.794*radsec) / math.Sin(c.hp) / c.srad
+	l0, _ := libration(lmb0+math.Pi+r*math.Cos(beta)*math.Sin(lmb0-lmb), r*beta)
+	chi := math.Atan2(math.Cos(sdelt)*math.Sin(salph-c.alpha), math.Sin(sdelt)*math.Cos(c.delta)-math.Cos(sdelt)*math.Sin(c.delta)*math.Cos(salph-c.alpha))
+	return LunarDisk{
+		L:           l / radian,
+		B:           b / radian,
+		P:           p / radian,
+		Colongitude: math.Mod(90-l0/radian+360, 360),
+		BrightLimb:  math.Mod(chi/radian+360, 360),
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright 2024 Matthew P. Dargan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ephem
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"time"
+)
+
+// A Shower is a meteor shower. Solar longitudes are referred to the
+// equinox of J2000.
+type Shower struct {
+	Code       string  // IAU code, such as "PER"
+	Name       string  // such as "Perseids"
+	Start, End float64 // solar longitudes at which activity begins and ends (degrees)
+	Peak       float64 // solar longitude at the peak (degrees)
+	ZHR        float64 // zenithal hourly rate at the peak
+	RA, Dec    float64 // radiant at the peak at J2000 (degrees)
+	V          float64 // geocentric velocity (km/s)
+	R          float64 // population index; if zero, it is 2.5
+}
+
+// Showers returns the meteor showers of the embedded catalog, the major
+// showers of the working list of the International Meteor Organization, in
+// order of their peaks through the year.
+func Showers() []Shower {
+	return slices.Clone(meteorShowers)
+}
+
+// ReadShowers reads meteor showers from a file in CSV format with a header
+// row naming its columns: code, name, start, peak, end, zhr, ra, dec, v, and
+// optionally r, with the meanings of the fields of [Shower].
+func ReadShowers(r io.Reader) ([]Shower, error) {
+	cr := newCSVReader(r)
+	if err := cr.header(); err != nil {
+		return nil, err
+	}
+	cols := []string{"start", "peak", "end", "zhr", "ra", "dec", "v"}
+	if !cr.has(append(cols, "code", "name")...) {
+		return nil, errors.New("missing code, name, start, peak, end, zhr, ra, dec, or v column")
+	}
+	var ss []Shower
+	for {
+		rec, err := cr.r.Read()
+		if err == io.EOF {
+			return ss, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.r.FieldPos(0)
+		s := Shower{Code: cr.field(rec, "code"), Name: cr.field(rec, "name")}
+		for i, v := range []*float64{&s.Start, &s.Peak, &s.End, &s.ZHR, &s.RA, &s.Dec, &s.V, &s.R} {
+			name, optional := "r", true
+			if i < len(cols) {
+				name, optional = cols[i], false
+			}
+			if *v, err = cr.float(rec, name, optional); err != nil {
+				return nil, fmt.Errorf("line %d: %v", line, err)
+			}
+		}
+		if s.Code == "" {
+			return nil, fmt.Errorf("line %d: missing code", line)
+		}
+		ss = append(ss, s)
+	}
+}
+
+// showers returns the embedded catalog of meteor showers with those of o,
+// each of which replaces any shower of the catalog with the same code.
+func (o *Observer) showers() []Shower {
+	ss := slices.DeleteFunc(Showers(), func(s Shower) bool {
+		return slices.ContainsFunc(o.Showers, func(t Shower) bool { return t.Code == s.Code })
+	})
+	return append(ss, o.Showers...)
+}
+
+// active reports whether the shower s is active at solar longitude lon
+// (J2000, degrees).
+func (s *Shower) active(lon float64) bool {
+	return math.Mod(lon-s.Start+720, 360) <= math.Mod(s.End-s.Start+720, 360)
+}
+
+// zhr returns the zenithal hourly rate of s at solar longitude lon (J2000,
+// degrees). The rate falls away from the peak exponentially to 1 at the
+// ends of activity.
+func (s *Shower) zhr(lon float64) float64 {
+	d := math.Remainder(lon-s.Peak, 360)
+	w := math.Mod(s.Peak-s.Start+720, 360)
+	if d > 0 {
+		w = math.Mod(s.End-s.Peak+720, 360)
+	}
+	if w == 0 || s.ZHR <= 1 {
+		return s.ZHR
+	}
+	return s.ZHR * math.Pow(10, -math.Log10(s.ZHR)*math.Abs(d)/w)
+}
+
+// A MeteorHour is the expected activity of a meteor shower at a time.
+type MeteorHour struct {
+	Time      time.Time
+	RadiantEl float64 // elevation of the radiant (degrees)
+	MoonEl    float64 // elevation of the moon (degrees)
+	MoonIllum float64 // illuminated fraction of the moon
+	Rate      float64 // estimated number of meteors seen in an hour
+}
+
+// A MeteorNight is the expected activity of a meteor shower during a night.
+type MeteorNight struct {
+	Shower Shower
+	ZHR    float64 // greatest zenithal hourly rate during the night
+	Hours  []MeteorHour
+}
+
+// MeteorOptions configure the estimates made by [Observer.Meteors]. A nil
+// *MeteorOptions is equivalent to a zero MeteorOptions.
+type MeteorOptions struct {
+	// LimitingMag is the magnitude of the faintest stars seen in a dark,
+	// moonless sky. If zero, it is 6.5.
+	LimitingMag float64
+}
+
+// Meteors returns the expected activity of the meteor showers that are
+// active on the night during the day starting at t, reported by [Showers]
+// and those of o, at each hour at which the sun is more than 12° below the
+// horizon. The hourly rate is the zenithal hourly rate reduced for the
+// elevation of the radiant and for the limiting magnitude, which moonlight
+// brightens by up to 2.5 magnitudes for a full moon at the zenith.
+func (o *Observer) Meteors(t time.Time, opts *MeteorOptions) []MeteorNight {
+	if opts == nil {
+		opts = new(MeteorOptions)
+	}
+	lm := opts.LimitingMag
+	if lm == 0 {
+		lm = 6.5
+	}
+	c := newState(o)
+	c.setup(o, t)
+	ns := make([]MeteorNight, len(c.showers))
+	for i := range ns {
+		ns[i].Shower = c.showers[i]
+	}
+	for h := range 24 {
+		var sun, moon, p Coord
+		tm := c.day + float64(h)/24
+		c.seTime(tm)
+		c.fSun()
+		c.obj(&sun)
+		if sun.El > -12 {
+			continue
+		}
+		// The solar longitude referred to the equinox of J2000.
+		lon := math.Mod((c.lmb2-c.phi)/radian-1.3969713*(c.eday-36525)/36525+720, 360)
+		c.moon()
+		c.obj(&moon)
+		sky := lm
+		if moon.El > 0 {
+			sky -= 2.5 * moon.Illum * math.Sin(moon.El*radian)
+		}
+		for i := range ns {
+			s := &ns[i].Shower
+			if !s.active(lon) {
+				continue
+			}
+			c.place(s.RA/15, s.Dec, 0, 0, 1e9, 36525) // J2000
+			c.obj(&p)
+			zhr := s.zhr(lon)
+			ns[i].ZHR = max(ns[i].ZHR, zhr)
+			r := s.R
+			if r == 0 {
+				r = 2.5
+			}
+			var rate float64
+			if p.El > 0 {
+				rate = zhr * math.Sin(p.El*radian) / math.Pow(r, 6.5-sky)
+			}
+			ns[i].Hours = append(ns[i].Hours, MeteorHour{
+				Time:      julianToTime(tm),
+				RadiantEl: p.El,
+				MoonEl:    moon.El,
+				MoonIllum: moon.Illum,
+				Rate:      rate,
+			})
+		}
+	}
+	return slices.DeleteFunc(ns, func(n MeteorNight) bool { return len(n.Hours) == 0 })
+}
+
+// showerPeak returns the time, in steps, of the peak of s during the
+// search, or -1.
+func (c *state) showerPeak(s *Shower) float64 {
+	// Carry the solar longitude from the equinox of J2000 to that of date,
+	// the date being that of the peak once it is found, so that a peak near
+	// the end of the search is not also found at the start of the next.
+	t := 0.
+	for range 2 {
+		lon := s.Peak + 1.3969713*(c.day+t*stepSize-36525)/36525
+		if t = c.sunLong(math.Remainder(lon, 360) * radian); t < 0 {
+			break
+		}
+	}
+	return t
+}
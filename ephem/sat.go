@@ -0,0 +1,552 @@
+// Copyright 2024 Matthew P. Dargan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ephem
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WGS 72 constants used by SGP4.
+const (
+	earthKm = 6378.135
+	muKm    = 398600.8
+	j2      = 0.001082616
+	j3      = -0.00000253881
+	j4      = -0.00000165597
+)
+
+var xke = 60 / math.Sqrt(earthKm*earthKm*earthKm/muKm)
+
+// A Satellite is an earth satellite described by a NORAD two-line element
+// set. It is propagated with the SGP4 model or, if its period is 225 minutes
+// or more, with the deep-space SDP4 model.
+type Satellite struct {
+	Name   string
+	Number int
+	Epoch  time.Time
+
+	// Elements at epoch, in radians and radians per minute.
+	incl, node, ecc, peri, m, n, bstar float64
+
+	// Coefficients computed by init.
+	simple                                                    bool
+	ao, con41, x1mth2, x7thm1, eta, cc1, cc4, cc5, d2, d3, d4 float64
+	delmo, sinmao, mdot, argpdot, nodedot, omgcof, xmcof      float64
+	nodecf, t2cof, t3cof, t4cof, t5cof, xlcof, aycof          float64
+	ds                                                        *deepSpace
+}
+
+// ReadSatellites reads two-line element sets, each optionally preceded by a
+// line naming the satellite. Blank lines are skipped.
+func ReadSatellites(r io.Reader) ([]Satellite, error) {
+	var (
+		sats []Satellite
+		name string
+		l1   string
+	)
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		l := strings.TrimRight(s.Text(), " \r")
+		switch {
+		case l == "":
+			continue
+		case strings.HasPrefix(l, "1 ") && l1 == "":
+			l1 = l
+		case strings.HasPrefix(l, "2 ") && l1 != "":
+			sat, err := parseTLE(name, l1, l)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", n, err)
+			}
+			sats = append(sats, sat)
+			name, l1 = "", ""
+		case l1 == "":
+			name = strings.TrimSpace(strings.TrimPrefix(l, "0 "))
+		default:
+			return nil, fmt.Errorf("line %d: missing line 2 of element set", n)
+		}
+	}
+	if l1 != "" {
+		return nil, errors.New("missing line 2 of last element set")
+	}
+	return sats, s.Err()
+}
+
+func parseTLE(name, l1, l2 string) (Satellite, error) {
+	if len(l1) < 69 || len(l2) < 69 {
+		return Satellite{}, errors.New("short element set")
+	}
+	for _, l := range []string{l1, l2} {
+		if checksum(l[:68]) != int(l[68]-'0') {
+			return Satellite{}, errors.New("bad checksum")
+		}
+	}
+	field := func(l string, i, j int) string {
+		return strings.TrimSpace(l[i:j])
+	}
+	var sat Satellite
+	var err error
+	if sat.Number, err = strconv.Atoi(field(l1, 2, 7)); err != nil {
+		return Satellite{}, err
+	}
+	sat.Name = name
+	if sat.Name == "" {
+		sat.Name = strconv.Itoa(sat.Number)
+	}
+	y, err := strconv.Atoi(field(l1, 18, 20))
+	if err != nil {
+		return Satellite{}, err
+	}
+	if y < 57 {
+		y += 2000
+	} else {
+		y += 1900
+	}
+	d, err := strconv.ParseFloat(field(l1, 20, 32), 64)
+	if err != nil {
+		return Satellite{}, err
+	}
+	sat.Epoch = time.Date(y, 1, 0, 0, 0, 0, 0, time.UTC).Add(time.Duration(d * secondsPerDay * float64(time.Second)))
+	if sat.bstar, err = expField(field(l1, 53, 61)); err != nil {
+		return Satellite{}, err
+	}
+	for _, f := range []struct {
+		v    *float64
+		i, j int
+	}{
+		{&sat.incl, 8, 16},
+		{&sat.node, 17, 25},
+		{&sat.peri, 34, 42},
+		{&sat.m, 43, 51},
+		{&sat.n, 52, 63},
+	} {
+		if *f.v, err = strconv.ParseFloat(field(l2, f.i, f.j), 64); err != nil {
+			return Satellite{}, err
+		}
+	}
+	if sat.ecc, err = strconv.ParseFloat("0."+field(l2, 26, 33), 64); err != nil {
+		return Satellite{}, err
+	}
+	sat.incl *= radian
+	sat.node *= radian
+	sat.peri *= radian
+	sat.m *= radian
+	sat.n *= twoPi / 1440
+	sat.init()
+	return sat, nil
+}
+
+// checksum returns the modulo 10 checksum of a line of an element set.
+func checksum(l string) int {
+	n := 0
+	for _, r := range l {
+		switch {
+		case r >= '0' && r <= '9':
+			n += int(r - '0')
+		case r == '-':
+			n++
+		}
+	}
+	return n % 10
+}
+
+// expField parses a number in the assumed-decimal exponential notation of
+// element sets, such as " 28098-4" for 0.28098e-4.
+func expField(s string) (float64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	sign := ""
+	if s[0] == '-' || s[0] == '+' {
+		sign, s = s[:1], s[1:]
+	}
+	i := strings.LastIndexAny(s, "+-")
+	if i <= 0 {
+		return 0, fmt.Errorf("bad number %q", s)
+	}
+	return strconv.ParseFloat(sign+"0."+s[:i]+"e"+s[i:], 64)
+}
+
+// init computes the SGP4 coefficients of s and, for a deep-space orbit,
+// the SDP4 coefficients.
+func (s *Satellite) init() {
+	const (
+		x2o3  = 2. / 3
+		j3oj2 = j3 / j2
+	)
+	ss := 78/earthKm + 1
+	qzms2t := math.Pow((120-78)/earthKm, 4)
+	// Recover the original mean motion and semimajor axis from the
+	// Kozai mean motion of the element set.
+	eccsq := s.ecc * s.ecc
+	omeosq := 1 - eccsq
+	rteosq := math.Sqrt(omeosq)
+	cosio := math.Cos(s.incl)
+	cosio2 := cosio * cosio
+	ak := math.Pow(xke/s.n, x2o3)
+	d1 := 0.75 * j2 * (3*cosio2 - 1) / (rteosq * omeosq)
+	del := d1 / (ak * ak)
+	adel := ak * (1 - del*del - del*(1./3+134*del*del/81))
+	del = d1 / (adel * adel)
+	s.n /= 1 + del
+	s.ao = math.Pow(xke/s.n, x2o3)
+	sinio := math.Sin(s.incl)
+	po := s.ao * omeosq
+	con42 := 1 - 5*cosio2
+	s.con41 = -con42 - cosio2 - cosio2
+	posq := po * po
+	rp := s.ao * (1 - s.ecc)
+	deep := twoPi/s.n >= 225
+	s.simple = rp < 220/earthKm+1 || deep
+	sfour := ss
+	qzms24 := qzms2t
+	perige := (rp - 1) * earthKm
+	if perige < 156 {
+		sfour = perige - 78
+		if perige < 98 {
+			sfour = 20
+		}
+		qzms24 = math.Pow((120-sfour)/earthKm, 4)
+		sfour = sfour/earthKm + 1
+	}
+	pinvsq := 1 / posq
+	tsi := 1 / (s.ao - sfour)
+	s.eta = s.ao * s.ecc * tsi
+	etasq := s.eta * s.eta
+	eeta := s.ecc * s.eta
+	psisq := math.Abs(1 - etasq)
+	coef := qzms24 * math.Pow(tsi, 4)
+	coef1 := coef / math.Pow(psisq, 3.5)
+	cc2 := coef1 * s.n * (s.ao*(1+1.5*etasq+eeta*(4+etasq)) + 0.375*j2*tsi/psisq*s.con41*(8+3*etasq*(8+etasq)))
+	s.cc1 = s.bstar * cc2
+	cc3 := 0.
+	if s.ecc > 1e-4 {
+		cc3 = -2 * coef * tsi * j3oj2 * s.n * sinio / s.ecc
+	}
+	s.x1mth2 = 1 - cosio2
+	s.cc4 = 2 * s.n * coef1 * s.ao * omeosq * (s.eta*(2+0.5*etasq) + s.ecc*(0.5+2*etasq) -
+		j2*tsi/(s.ao*psisq)*(-3*s.con41*(1-2*eeta+etasq*(1.5-0.5*eeta))+0.75*s.x1mth2*(2*etasq-eeta*(1+etasq))*math.Cos(2*s.peri)))
+	s.cc5 = 2 * coef1 * s.ao * omeosq * (1 + 2.75*(etasq+eeta) + eeta*etasq)
+	cosio4 := cosio2 * cosio2
+	temp1 := 1.5 * j2 * pinvsq * s.n
+	temp2 := 0.5 * temp1 * j2 * pinvsq
+	temp3 := -0.46875 * j4 * pinvsq * pinvsq * s.n
+	s.mdot = s.n + 0.5*temp1*rteosq*s.con41 + 0.0625*temp2*rteosq*(13-78*cosio2+137*cosio4)
+	s.argpdot = -0.5*temp1*con42 + 0.0625*temp2*(7-114*cosio2+395*cosio4) + temp3*(3-36*cosio2+49*cosio4)
+	xhdot1 := -temp1 * cosio
+	s.nodedot = xhdot1 + (0.5*temp2*(4-19*cosio2)+2*temp3*(3-7*cosio2))*cosio
+	s.omgcof = s.bstar * cc3 * math.Cos(s.peri)
+	if s.ecc > 1e-4 {
+		s.xmcof = -x2o3 * coef * s.bstar / eeta
+	}
+	s.nodecf = 3.5 * omeosq * xhdot1 * s.cc1
+	s.t2cof = 1.5 * s.cc1
+	s.xlcof, s.aycof = lcof(sinio, cosio)
+	s.delmo = math.Pow(1+s.eta*math.Cos(s.m), 3)
+	s.sinmao = math.Sin(s.m)
+	s.x7thm1 = 7*cosio2 - 1
+	if !s.simple {
+		cc1sq := s.cc1 * s.cc1
+		s.d2 = 4 * s.ao * tsi * cc1sq
+		temp := s.d2 * tsi * s.cc1 / 3
+		s.d3 = (17*s.ao + sfour) * temp
+		s.d4 = 0.5 * temp * s.ao * tsi * (221*s.ao + 31*sfour) * s.cc1
+		s.t3cof = s.d2 + 2*cc1sq
+		s.t4cof = 0.25 * (3*s.d3 + s.cc1*(12*s.d2+10*cc1sq))
+		s.t5cof = 0.2 * (3*s.d4 + 12*s.cc1*s.d3 + 6*s.d2*s.d2 + 15*cc1sq*(2*s.d2+cc1sq))
+	}
+	if deep {
+		s.initDeep(timeToJulian(s.Epoch))
+	}
+}
+
+// lcof returns the coefficients of the long-period periodics for an orbit
+// of the given inclination.
+func lcof(sini, cosi float64) (xlcof, aycof float64) {
+	const j3oj2 = j3 / j2
+	d := 1 + cosi
+	if math.Abs(d) <= 1.5e-12 {
+		d = 1.5e-12
+	}
+	return -0.25 * j3oj2 * sini * (3 + 5*cosi) / d, -0.5 * j3oj2 * sini
+}
+
+// propagate returns the position (km) and velocity (km/s) of s in the true
+// equator, mean equinox frame t minutes after its epoch.
+func (s *Satellite) propagate(t float64) (r, v [3]float64, err error) {
+	// Secular effects of gravity and drag.
+	xmdf := s.m + s.mdot*t
+	argpdf := s.peri + s.argpdot*t
+	nodedf := s.node + s.nodedot*t
+	argpm := argpdf
+	mm := xmdf
+	t2 := t * t
+	nodem := nodedf + s.nodecf*t2
+	tempa := 1 - s.cc1*t
+	tempe := s.bstar * s.cc4 * t
+	templ := s.t2cof * t2
+	if !s.simple {
+		delomg := s.omgcof * t
+		delm := s.xmcof * (math.Pow(1+s.eta*math.Cos(xmdf), 3) - s.delmo)
+		temp := delomg + delm
+		mm = xmdf + temp
+		argpm = argpdf - temp
+		t3 := t2 * t
+		t4 := t3 * t
+		tempa -= s.d2*t2 + s.d3*t3 + s.d4*t4
+		tempe += s.bstar * s.cc5 * (math.Sin(mm) - s.sinmao)
+		templ += s.t3cof*t3 + t4*(s.t4cof+t*s.t5cof)
+	}
+	em, inclm, nm := s.ecc, s.incl, s.n
+	if s.ds != nil {
+		em, argpm, inclm, mm, nodem, nm = s.secular(t, em, argpm, inclm, mm, nodem)
+		if nm <= 0 {
+			return r, v, errors.New("satellite has decayed")
+		}
+	}
+	am := math.Pow(xke/nm, 2./3) * tempa * tempa
+	nm = xke / math.Pow(am, 1.5)
+	em -= tempe
+	if em >= 1 || em < -0.001 {
+		return r, v, errors.New("satellite orbit is no longer elliptic")
+	}
+	em = max(em, 1e-6)
+	mm += s.n * templ
+	xlm := mm + argpm + nodem
+	nodem = math.Mod(nodem, twoPi)
+	argpm = math.Mod(argpm, twoPi)
+	xlm = math.Mod(xlm, twoPi)
+	mm = math.Mod(xlm-argpm-nodem, twoPi)
+	// Long-period periodics.
+	ep, xincp, nodep, argpp, mp := em, inclm, nodem, argpm, mm
+	con41, x1mth2, x7thm1, xlcof, aycof := s.con41, s.x1mth2, s.x7thm1, s.xlcof, s.aycof
+	if s.ds != nil {
+		ep, xincp, nodep, argpp, mp = s.periodics(t, ep, xincp, nodep, argpp, mp)
+		if xincp < 0 {
+			xincp = -xincp
+			nodep += math.Pi
+			argpp -= math.Pi
+		}
+		if ep < 0 || ep > 1 {
+			return r, v, errors.New("satellite orbit is no longer elliptic")
+		}
+		cosisq := math.Cos(xincp) * math.Cos(xincp)
+		con41 = 3*cosisq - 1
+		x1mth2 = 1 - cosisq
+		x7thm1 = 7*cosisq - 1
+		xlcof, aycof = lcof(math.Sin(xincp), math.Cos(xincp))
+	}
+	sinip, cosip := math.Sincos(xincp)
+	axnl := ep * math.Cos(argpp)
+	temp := 1 / (am * (1 - ep*ep))
+	aynl := ep*math.Sin(argpp) + temp*aycof
+	xl := mp + argpp + nodep + temp*xlcof*axnl
+	// Solve Kepler's equation.
+	u := math.Mod(xl-nodep, twoPi)
+	eo1 := u
+	var sineo1, coseo1 float64
+	for range 10 {
+		sineo1, coseo1 = math.Sincos(eo1)
+		d := (u - aynl*coseo1 + axnl*sineo1 - eo1) / (1 - coseo1*axnl - sineo1*aynl)
+		d = max(-0.95, min(d, 0.95))
+		eo1 += d
+		if math.Abs(d) < 1e-12 {
+			break
+		}
+	}
+	// Short-period periodics.
+	ecose := axnl*coseo1 + aynl*sineo1
+	esine := axnl*sineo1 - aynl*coseo1
+	el2 := axnl*axnl + aynl*aynl
+	pl := am * (1 - el2)
+	if pl < 0 {
+		return r, v, errors.New("satellite orbit is no longer elliptic")
+	}
+	rl := am * (1 - ecose)
+	rdotl := math.Sqrt(am) * esine / rl
+	rvdotl := math.Sqrt(pl) / rl
+	betal := math.Sqrt(1 - el2)
+	temp = esine / (1 + betal)
+	sinu := am / rl * (sineo1 - aynl - axnl*temp)
+	cosu := am / rl * (coseo1 - axnl + aynl*temp)
+	su := math.Atan2(sinu, cosu)
+	sin2u := (cosu + cosu) * sinu
+	cos2u := 1 - 2*sinu*sinu
+	temp = 1 / pl
+	temp1 := 0.5 * j2 * temp
+	temp2 := temp1 * temp
+	mrt := rl*(1-1.5*temp2*betal*con41) + 0.5*temp1*x1mth2*cos2u
+	if mrt < 1 {
+		return r, v, errors.New("satellite has decayed")
+	}
+	su -= 0.25 * temp2 * x7thm1 * sin2u
+	xnode := nodep + 1.5*temp2*cosip*sin2u
+	xinc := xincp + 1.5*temp2*cosip*sinip*cos2u
+	mvt := rdotl - nm*temp1*x1mth2*sin2u/xke
+	rvdot := rvdotl + nm*temp1*(x1mth2*cos2u+1.5*con41)/xke
+	// Orientation vectors.
+	sinsu, cossu := math.Sincos(su)
+	snod, cnod := math.Sincos(xnode)
+	sini, cosi := math.Sincos(xinc)
+	xmx := -snod * cosi
+	xmy := cnod * cosi
+	uv := [3]float64{xmx*sinsu + cnod*cossu, xmy*sinsu + snod*cossu, sini * sinsu}
+	vv := [3]float64{xmx*cossu - cnod*sinsu, xmy*cossu - snod*sinsu, sini * cossu}
+	vkms := earthKm * xke / 60
+	for i := range 3 {
+		r[i] = mrt * uv[i] * earthKm
+		v[i] = (mvt*uv[i] + rvdot*vv[i]) * vkms
+	}
+	return r, v, nil
+}
+
+// A PassPoint is the position of a satellite at a moment of a pass.
+type PassPoint struct {
+	Time   time.Time
+	Az, El float64 // azimuth and elevation (degrees)
+	Sunlit bool    // outside the shadow of the earth
+}
+
+// A Pass is a passage of a satellite above the horizon of an [Observer].
+type Pass struct {
+	Rise, Culmination, Set PassPoint
+
+	// Visible reports whether the satellite is sunlit at some time during
+	// the pass while the sun is more than 6° below the observer's horizon.
+	Visible bool
+}
+
+// passStep is the interval, in steps, at which the elevation of a satellite
+// is sampled: 20 seconds.
+const passStep = 20 / (secondsPerDay * stepSize)
+
+// Passes returns the passes of s that rise during the day starting at t.
+func (o *Observer) Passes(s *Satellite, t time.Time) ([]Pass, error) {
+	c := newState(o)
+	c.setup(o, t)
+	c.tol = 1 / (secondsPerDay * stepSize)
+	d := c.day
+	for i := range c.oSun.point {
+		c.seTime(d)
+		c.fSun()
+		c.obj(&c.oSun.point[i])
+		d += stepSize
+	}
+	var perr error
+	el := func(x float64) float64 {
+		p, err := c.satAt(o, s, x)
+		if err != nil {
+			perr = err
+		}
+		return p.El
+	}
+	var ps []Pass
+	x := 0.
+	e1 := el(x)
+	if perr != nil {
+		return nil, perr
+	}
+	// Skip a pass in progress at t.
+	for e1 > 0 && x < numPoints {
+		x += passStep
+		e1 = el(x)
+	}
+	for x < numPoints {
+		e2 := el(x + passStep)
+		if perr != nil {
+			return nil, perr
+		}
+		if e1 > 0 || e2 <= 0 {
+			x += passStep
+			e1 = e2
+			continue
+		}
+		rise := c.root(el, x, x+passStep, e1, e2)
+		// Follow the satellite until it sets.
+		x += passStep
+		e1 = e2
+		var p Pass
+		top, etop := x, e1
+		for e1 > 0 {
+			pt, _ := c.satAt(o, s, x)
+			if pt.Sunlit && c.sunEl(x) < -6 {
+				p.Visible = true
+			}
+			if e1 > etop {
+				top, etop = x, e1
+			}
+			x += passStep
+			e1 = el(x)
+		}
+		set := c.root(el, x-passStep, x, el(x-passStep), e1)
+		culm := c.maximum(el, max(rise, top-passStep), min(set, top+passStep))
+		if perr != nil {
+			return nil, perr
+		}
+		p.Rise, _ = c.satAt(o, s, rise)
+		p.Culmination, _ = c.satAt(o, s, culm)
+		p.Set, _ = c.satAt(o, s, set)
+		ps = append(ps, p)
+	}
+	return ps, nil
+}
+
+// satAt returns the position of s as seen by o at x steps after the start
+// of the search.
+func (c *state) satAt(o *Observer, s *Satellite, x float64) (PassPoint, error) {
+	d := c.day + x*stepSize
+	r, _, err := s.propagate((d - timeToJulian(s.Epoch)) * 1440)
+	if err != nil {
+		return PassPoint{}, err
+	}
+	// Rotate to the earth-fixed frame by the Greenwich sidereal time.
+	g := gmst(d)
+	sg, cg := math.Sincos(g)
+	r = [3]float64{cg*r[0] + sg*r[1], -sg*r[0] + cg*r[1], r[2]}
+	// The observer on the WGS 84 ellipsoid.
+	const f = 1 / 298.257223563
+	e2 := f * (2 - f)
+	sl, cl := math.Sincos(o.Lat * radian)
+	sn, cn := math.Sincos(-o.WLong * radian)
+	n := 6378.137 / math.Sqrt(1-e2*sl*sl)
+	h := o.Elev / 1000
+	ob := [3]float64{(n + h) * cl * cn, (n + h) * cl * sn, (n*(1-e2) + h) * sl}
+	var rho [3]float64
+	for i := range 3 {
+		rho[i] = r[i] - ob[i]
+	}
+	south := sl*cn*rho[0] + sl*sn*rho[1] - cl*rho[2]
+	east := -sn*rho[0] + cn*rho[1]
+	up := cl*cn*rho[0] + cl*sn*rho[1] + sl*rho[2]
+	p := PassPoint{
+		Time: julianToTime(d),
+		Az:   math.Mod(math.Atan2(east, -south)/radian+360, 360),
+		El:   math.Atan2(up, math.Hypot(south, east)) / radian,
+	}
+	// The satellite is in the shadow of the earth, taken as a cylinder, if
+	// it is behind the earth and within its radius of the line to the sun.
+	c.seTime(d)
+	c.fSun()
+	sd, cd := math.Sincos(c.delta)
+	sa, ca := math.Sincos(c.alpha - g)
+	sun := [3]float64{cd * ca, cd * sa, sd}
+	dot := r[0]*sun[0] + r[1]*sun[1] + r[2]*sun[2]
+	perp := math.Sqrt(max(0, r[0]*r[0]+r[1]*r[1]+r[2]*r[2]-dot*dot))
+	p.Sunlit = dot > 0 || perp > earthKm
+	return p, nil
+}
+
+// gmst returns the Greenwich mean sidereal time in radians at day d.
+func gmst(d float64) float64 {
+	t := (d - 36525) / 36525 // Julian centuries from J2000.
+	s := 67310.54841 + (876600*3600+8640184.812866)*t + 0.093104*t*t - 6.2e-6*t*t*t
+	return math.Mod(math.Mod(s, secondsPerDay)/240*radian+twoPi, twoPi)
+}
@@ -0,0 +1,80 @@
+// Copyright 2024 Matthew P. Dargan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ephem
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// The test satellites of Spacetrack Report #3, with their checksums
+// corrected: 88888 is a near-earth orbit and 11801 a deep-space one.
+const (
+	tle88888 = "1 88888U          80275.98708465  .00073094  13844-3  66816-4 0     9\n" +
+		"2 88888  72.8435 115.9689 0086731  52.6988 110.5714 16.05824518   103\n"
+	tle11801 = "1 11801U          80230.29629788  .01431103  00000-0  14311-1 0     2\n" +
+		"2 11801  46.7916 230.4354 7318036  47.4722  10.4117  2.28537848    13\n"
+)
+
+// TestPropagate checks positions against those published in Spacetrack
+// Report #3 for SGP4 and SDP4.
+func TestPropagate(t *testing.T) {
+	tests := []struct {
+		tle  string
+		t    float64 // minutes after epoch
+		want [3]float64
+	}{
+		{tle88888, 0, [3]float64{2328.97048951, -5995.22076416, 1719.97067261}},
+		{tle88888, 360, [3]float64{2456.10705566, -6071.93853760, 1222.89727783}},
+		{tle88888, 720, [3]float64{2567.56195068, -6112.50384522, 713.96397400}},
+		{tle11801, 0, [3]float64{7473.37066650, 428.95261765, 5828.74786377}},
+		{tle11801, 360, [3]float64{-3305.22537232, 32410.86328125, -24697.17675781}},
+		{tle11801, 720, [3]float64{14271.28759766, 24110.46411133, -4725.76837158}},
+	}
+	for _, tt := range tests {
+		sats, err := ReadSatellites(strings.NewReader(tt.tle))
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, _, err := sats[0].propagate(tt.t)
+		if err != nil {
+			t.Fatalf("%s at %v min: %v", sats[0].Name, tt.t, err)
+		}
+		if d := math.Sqrt(sq(r[0]-tt.want[0]) + sq(r[1]-tt.want[1]) + sq(r[2]-tt.want[2])); d > 1 {
+			t.Errorf("%s at %v min: position %.3f km, want %.3f km", sats[0].Name, tt.t, r, tt.want)
+		}
+	}
+}
+
+func sq(x float64) float64 {
+	return x * x
+}
+
+func TestReadSatellitesErrors(t *testing.T) {
+	l1, l2, _ := strings.Cut(tle88888, "\n")
+	tests := []struct {
+		name, in, err string
+	}{
+		{"bad checksum", l1[:68] + "0\n" + l2, "line 2: bad checksum"},
+		{"short line", l1 + "\n" + l2[:60] + "\n", "line 2: short element set"},
+		{"bad number", l1[:2] + "8888x" + l1[7:68] + "1\n" + l2, `line 2: strconv.Atoi: parsing "8888x": invalid syntax`},
+		{"bad epoch", l1[:20] + "2x5.9870" + l1[28:68] + "2\n" + l2, `line 2: strconv.ParseFloat: parsing "2x5.98708465": invalid syntax`},
+		{"bad drag term", l1[:53] + " 66816 4" + l1[61:68] + "8\n" + l2, `line 2: bad number "66816 4"`},
+		{"bad inclination", l1 + "\n" + l2[:8] + " 72.84x5" + l2[16:68] + "0\n", `line 2: strconv.ParseFloat: parsing "72.84x5": invalid syntax`},
+		{"missing line 2", l1 + "\n", "missing line 2 of last element set"},
+		{"line 1 twice", l1 + "\n" + l1 + "\n", "line 2: missing line 2 of element set"},
+	}
+	for _, tt := range tests {
+		_, err := ReadSatellites(strings.NewReader(tt.in))
+		if err == nil {
+			t.Errorf("%s: no error", tt.name)
+			continue
+		}
+		if err.Error() != tt.err {
+			t.Errorf("%s: error %q, want %q", tt.name, err, tt.err)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 Matthew P. Dargan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ephem
+
+import (
+	"math"
+	"time"
+)
+
+// A SaturnAspect is the aspect of the rings of Saturn and the position of
+// Titan.
+type SaturnAspect struct {
+	B        float64   // saturnicentric latitude of the earth referred to the ring plane (degrees), positive north
+	BSun     float64   // saturnicentric latitude of the sun referred to the ring plane (degrees)
+	P        float64   // position angle of the northern semiminor axis of the rings, east of north (degrees)
+	Crossing time.Time // next passage of the earth through the ring plane
+	TitanX   float64   // offset of Titan from the center of Saturn in equatorial radii, positive to the west
+	TitanY   float64   // offset of Titan to the north
+	Behind   bool      // whether Titan is farther away than Saturn
+}
+
+// ringCrossingYears is how far ahead a passage of the earth through the
+// ring plane is sought: the longer half of Saturn's orbit, with some to
+// spare.
+const ringCrossingYears = 17
+
+// Saturn returns the aspect of the rings of Saturn and the position of
+// Titan at t.
+func (o *Observer) Saturn(t time.Time) SaturnAspect {
+	c := newState(o)
+	c.setup(o, t)
+	c.seTime(c.day)
+	c.sat()
+	var p Coord
+	c.obj(&p)
+	a := SaturnAspect{
+		B:    c.ringB / radian,
+		BSun: c.ringBSun / radian,
+		P:    math.Mod(c.ringP/radian+360, 360),
+	}
+	a.TitanX, a.TitanY, a.Behind = c.titan(p)
+	// Step through the ring plane by ten days, which is short enough to
+	// catch each of three passages near a Saturnian equinox.
+	c.tol = 60 / (secondsPerDay * stepSize)
+	f := func(x float64) float64 {
+		c.seTime(c.day + x*stepSize)
+		c.sat()
+		return c.ringB
+	}
+	const step = 10 / stepSize
+	f1 := c.ringB
+	for x := 0.; x < ringCrossingYears*365.25/stepSize; x += step {
+		f2 := f(x + step)
+		if (f1 > 0) != (f2 > 0) {
+			a.Crossing = julianToTime(c.day + c.root(f, x, x+step, f1, f2)*stepSize)
+			break
+		}
+		f1 = f2
+	}
+	return a
+}
+
+// titan returns the offset of Titan from Saturn, whose place is p, in
+// equatorial radii of Saturn to the west and north, and whether it is
+// behind Saturn. The orbit is that of Meeus, less its small periodic terms,
+// referred to the ecliptic and equinox of B1950.
+func (c *state) titan(p Coord) (x, y float64, behind bool) {
+	// Correct for light time.
+	t4 := c.eday - p.Dist*0.0057755 + 3652
+	t5 := t4 / 365.25
+	w3 := (42.0 - 0.5118*t5) * radian
+	l := (261.1582 + 22.57697855*t4 + 0.074025*math.Sin(w3)) * radian
+	incl := (27.45141 + 0.295999*math.Cos(w3)) * radian
+	node := (168.66925 + 0.628808*math.Sin(w3)) * radian
+	peri := (276.59 + 0.5118*t5) * radian
+	const ecc, a = 0.029092, 20.216193
+	anom := math.Remainder(l-peri, twoPi)
+	enom := anom + ecc*math.Sin(anom)
+	for {
+		dele := (anom - enom + ecc*math.Sin(enom)) / (1 - ecc*math.Cos(enom))
+		enom += dele
+		if math.Abs(dele) <= converge {
+			break
+		}
+	}
+	vnom := 2 * math.Atan2(math.Sqrt((1+ecc)/(1-ecc))*math.Sin(enom/2), math.Cos(enom/2))
+	r := a * (1 - ecc*math.Cos(enom))
+	// Saturnicentric ecliptic coordinates, carried from the equinox of
+	// B1950 to that of date by the general precession in longitude.
+	b1950 := (1950-1900)*365.2422 + 0.313
+	u := vnom + peri - node
+	lon := node + (1.3969713*radian)*(c.eday-b1950)/36525
+	xe := r * (math.Cos(u)*math.Cos(lon) - math.Sin(u)*math.Sin(lon)*math.Cos(incl))
+	ye := r * (math.Cos(u)*math.Sin(lon) + math.Sin(u)*math.Cos(lon)*math.Cos(incl))
+	ze := r * math.Sin(u) * math.Sin(incl)
+	// Equatorial coordinates.
+	xq := xe
+	yq := ye*math.Cos(c.obliq) - ze*math.Sin(c.obliq)
+	zq := ye*math.Sin(c.obliq) + ze*math.Cos(c.obliq)
+	// Project onto the sky at the place of Saturn.
+	sa, ca := math.Sincos(p.RA)
+	sd, cd := math.Sincos(p.Decl)
+	x = xq*sa - yq*ca
+	y = -xq*sd*ca - yq*sd*sa + zq*cd
+	behind = xq*cd*ca+yq*cd*sa+zq*sd > 0
+	return x, y, behind
+}
@@ -0,0 +1,408 @@
+// Copyright 2024 Matthew P. Dargan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ephem
+
+import "math"
+
+// Constants of the lunar and solar perturbations of SDP4.
+const (
+	zes    = 0.01675
+	zel    = 0.05490
+	zns    = 1.19459e-5
+	znl    = 1.5835218e-4
+	c1ss   = 2.9864797e-6
+	c1l    = 4.7968065e-7
+	zsinis = 0.39785416
+	zcosis = 0.91744867
+	zcosgs = 0.1945905
+	zsings = -0.98088458
+	rptim  = 4.37526908801129966e-3 // rotation of the earth (radians per minute)
+)
+
+// deepSpace holds the SDP4 coefficients of a satellite whose period is 225
+// minutes or more, for which the attraction of the moon and the sun and,
+// for orbits of 12 and 24 hours, resonance with the geopotential matter.
+type deepSpace struct {
+	gsto float64 // Greenwich sidereal time at epoch
+
+	// Long-period periodics.
+	e3, ee2, se2, se3, sgh2, sgh3, sgh4, sh2, sh3, si2, si3, sl2, sl3, sl4 float64
+	xgh2, xgh3, xgh4, xh2, xh3, xi2, xi3, xl2, xl3, xl4, zmol, zmos        float64
+
+	// Secular rates.
+	dedt, didt, dmdt, dnodt, domdt float64
+
+	// Resonance: 1 for synchronous orbits, 2 for 12-hour orbits.
+	irez                                                                 int
+	d2201, d2211, d3210, d3222, d4410, d4422, d5220, d5232, d5421, d5433 float64
+	del1, del2, del3, xfact, xlamo                                       float64
+}
+
+// initDeep computes the deep-space coefficients of s, whose epoch is day
+// epoch.
+func (s *Satellite) initDeep(epoch float64) {
+	ds := &deepSpace{gsto: gmst(epoch)}
+	s.ds = ds
+	snodm, cnodm := math.Sincos(s.node)
+	sinomm, cosomm := math.Sincos(s.peri)
+	sinim, cosim := math.Sincos(s.incl)
+	emsq := s.ecc * s.ecc
+	betasq := 1 - emsq
+	rtemsq := math.Sqrt(betasq)
+	// The orbit of the moon at epoch.
+	xnodce := math.Mod(4.5236020-9.2422029e-4*epoch, twoPi)
+	stem, ctem := math.Sincos(xnodce)
+	zcosil := 0.91375164 - 0.03568096*ctem
+	zsinil := math.Sqrt(1 - zcosil*zcosil)
+	zsinhl := 0.089683511 * stem / zsinil
+	zcoshl := math.Sqrt(1 - zsinhl*zsinhl)
+	gam := 5.8351514 + 0.0019443680*epoch
+	zx := math.Atan2(0.39785416*stem/zsinil, zcoshl*ctem+0.91744867*zsinhl*stem)
+	zx += gam - xnodce
+	zsingl, zcosgl := math.Sincos(zx)
+	// Coefficients for the sun, then the moon.
+	type coef struct {
+		s1, s2, s3, s4, s5, s6, s7                              float64
+		z1, z2, z3, z11, z12, z13, z21, z22, z23, z31, z32, z33 float64
+	}
+	var sun, moon coef
+	zcosg, zsing, zcosi, zsini, zcosh, zsinh := zcosgs, zsings, zcosis, zsinis, cnodm, snodm
+	cc := c1ss
+	for _, k := range []*coef{&sun, &moon} {
+		a1 := zcosg*zcosh + zsing*zcosi*zsinh
+		a3 := -zsing*zcosh + zcosg*zcosi*zsinh
+		a7 := -zcosg*zsinh + zsing*zcosi*zcosh
+		a8 := zsing * zsini
+		a9 := zsing*zsinh + zcosg*zcosi*zcosh
+		a10 := zcosg * zsini
+		a2 := cosim*a7 + sinim*a8
+		a4 := cosim*a9 + sinim*a10
+		a5 := -sinim*a7 + cosim*a8
+		a6 := -sinim*a9 + cosim*a10
+		x1 := a1*cosomm + a2*sinomm
+		x2 := a3*cosomm + a4*sinomm
+		x3 := -a1*sinomm + a2*cosomm
+		x4 := -a3*sinomm + a4*cosomm
+		x5 := a5 * sinomm
+		x6 := a6 * sinomm
+		x7 := a5 * cosomm
+		x8 := a6 * cosomm
+		k.z31 = 12*x1*x1 - 3*x3*x3
+		k.z32 = 24*x1*x2 - 6*x3*x4
+		k.z33 = 12*x2*x2 - 3*x4*x4
+		k.z1 = 3*(a1*a1+a2*a2) + k.z31*emsq
+		k.z2 = 6*(a1*a3+a2*a4) + k.z32*emsq
+		k.z3 = 3*(a3*a3+a4*a4) + k.z33*emsq
+		k.z11 = -6*a1*a5 + emsq*(-24*x1*x7-6*x3*x5)
+		k.z12 = -6*(a1*a6+a3*a5) + emsq*(-24*(x2*x7+x1*x8)-6*(x3*x6+x4*x5))
+		k.z13 = -6*a3*a6 + emsq*(-24*x2*x8-6*x4*x6)
+		k.z21 = 6*a2*a5 + emsq*(24*x1*x5-6*x3*x7)
+		k.z22 = 6*(a4*a5+a2*a6) + emsq*(24*(x2*x5+x1*x6)-6*(x4*x7+x3*x8))
+		k.z23 = 6*a4*a6 + emsq*(24*x2*x6-6*x4*x8)
+		k.z1 += k.z1 + betasq*k.z31
+		k.z2 += k.z2 + betasq*k.z32
+		k.z3 += k.z3 + betasq*k.z33
+		k.s3 = cc / s.n
+		k.s2 = -0.5 * k.s3 / rtemsq
+		k.s4 = k.s3 * rtemsq
+		k.s1 = -15 * s.ecc * k.s4
+		k.s5 = x1*x3 + x2*x4
+		k.s6 = x2*x3 + x1*x4
+		k.s7 = x2*x4 - x1*x3
+		zcosg, zsing, zcosi, zsini = zcosgl, zsingl, zcosil, zsinil
+		zcosh = zcoshl*cnodm + zsinhl*snodm
+		zsinh = snodm*zcoshl - cnodm*zsinhl
+		cc = c1l
+	}
+	ds.zmol = math.Mod(4.7199672+0.22997150*epoch-gam, twoPi)
+	ds.zmos = math.Mod(6.2565837+0.017201977*epoch, twoPi)
+	ds.se2 = 2 * sun.s1 * sun.s6
+	ds.se3 = 2 * sun.s1 * sun.s7
+	ds.si2 = 2 * sun.s2 * sun.z12
+	ds.si3 = 2 * sun.s2 * (sun.z13 - sun.z11)
+	ds.sl2 = -2 * sun.s3 * sun.z2
+	ds.sl3 = -2 * sun.s3 * (sun.z3 - sun.z1)
+	ds.sl4 = -2 * sun.s3 * (-21 - 9*emsq) * zes
+	ds.sgh2 = 2 * sun.s4 * sun.z32
+	ds.sgh3 = 2 * sun.s4 * (sun.z33 - sun.z31)
+	ds.sgh4 = -18 * sun.s4 * zes
+	ds.sh2 = -2 * sun.s2 * sun.z22
+	ds.sh3 = -2 * sun.s2 * (sun.z23 - sun.z21)
+	ds.ee2 = 2 * moon.s1 * moon.s6
+	ds.e3 = 2 * moon.s1 * moon.s7
+	ds.xi2 = 2 * moon.s2 * moon.z12
+	ds.xi3 = 2 * moon.s2 * (moon.z13 - moon.z11)
+	ds.xl2 = -2 * moon.s3 * moon.z2
+	ds.xl3 = -2 * moon.s3 * (moon.z3 - moon.z1)
+	ds.xl4 = -2 * moon.s3 * (-21 - 9*emsq) * zel
+	ds.xgh2 = 2 * moon.s4 * moon.z32
+	ds.xgh3 = 2 * moon.s4 * (moon.z33 - moon.z31)
+	ds.xgh4 = -18 * moon.s4 * zel
+	ds.xh2 = -2 * moon.s2 * moon.z22
+	ds.xh3 = -2 * moon.s2 * (moon.z23 - moon.z21)
+
+	// Secular rates from the sun and the moon. The rates of the node are
+	// dropped for orbits within 3° of the equator.
+	equatorial := s.incl < 5.2359877e-2 || s.incl > math.Pi-5.2359877e-2
+	ses := sun.s1 * zns * sun.s5
+	sis := sun.s2 * zns * (sun.z11 + sun.z13)
+	sls := -zns * sun.s3 * (sun.z1 + sun.z3 - 14 - 6*emsq)
+	sghs := sun.s4 * zns * (sun.z31 + sun.z33 - 6)
+	shs := -zns * sun.s2 * (sun.z21 + sun.z23)
+	if equatorial {
+		shs = 0
+	}
+	if sinim != 0 {
+		shs /= sinim
+	}
+	sgs := sghs - cosim*shs
+	ds.dedt = ses + moon.s1*znl*moon.s5
+	ds.didt = sis + moon.s2*znl*(moon.z11+moon.z13)
+	ds.dmdt = sls - znl*moon.s3*(moon.z1+moon.z3-14-6*emsq)
+	sghl := moon.s4 * znl * (moon.z31 + moon.z33 - 6)
+	shll := -znl * moon.s2 * (moon.z21 + moon.z23)
+	if equatorial {
+		shll = 0
+	}
+	ds.domdt = sgs + sghl
+	ds.dnodt = shs
+	if sinim != 0 {
+		ds.domdt -= cosim / sinim * shll
+		ds.dnodt += shll / sinim
+	}
+
+	// Resonance with the geopotential.
+	switch {
+	case s.n > 0.0034906585 && s.n < 0.0052359877:
+		ds.irez = 1
+	case s.n >= 8.26e-3 && s.n <= 9.24e-3 && s.ecc >= 0.5:
+		ds.irez = 2
+	default:
+		return
+	}
+	const (
+		q22    = 1.7891679e-6
+		q31    = 2.1460748e-6
+		q33    = 2.2123015e-7
+		root22 = 1.7891679e-6
+		root32 = 3.7393792e-7
+		root44 = 7.3636953e-9
+		root52 = 1.1428639e-7
+		root54 = 2.1765803e-9
+	)
+	aonv := math.Pow(s.n/xke, 2./3)
+	theta := ds.gsto
+	if ds.irez == 1 {
+		// Synchronous orbits.
+		g200 := 1 + emsq*(-2.5+0.8125*emsq)
+		g310 := 1 + 2*emsq
+		g300 := 1 + emsq*(-6+6.60937*emsq)
+		f220 := 0.75 * (1 + cosim) * (1 + cosim)
+		f311 := 0.9375*sinim*sinim*(1+3*cosim) - 0.75*(1+cosim)
+		f330 := 1.875 * math.Pow(1+cosim, 3)
+		del1 := 3 * s.n * s.n * aonv * aonv
+		ds.del2 = 2 * del1 * f220 * g200 * q22
+		ds.del3 = 3 * del1 * f330 * g300 * q33 * aonv
+		ds.del1 = del1 * f311 * g310 * q31 * aonv
+		ds.xlamo = math.Mod(s.m+s.node+s.peri-theta, twoPi)
+		ds.xfact = s.mdot + s.argpdot + s.nodedot - rptim + ds.dmdt + ds.domdt + ds.dnodt - s.n
+		return
+	}
+	// Orbits of 12 hours.
+	em := s.ecc
+	eoc := em * emsq
+	g201 := -0.306 - (em-0.64)*0.440
+	var g211, g310, g322, g410, g422, g520, g521, g532, g533 float64
+	if em <= 0.65 {
+		g211 = 3.616 - 13.2470*em + 16.2900*emsq
+		g310 = -19.302 + 117.3900*em - 228.4190*emsq + 156.5910*eoc
+		g322 = -18.9068 + 109.7927*em - 214.6334*emsq + 146.5816*eoc
+		g410 = -41.122 + 242.6940*em - 471.0940*emsq + 313.9530*eoc
+		g422 = -146.407 + 841.8800*em - 1629.014*emsq + 1083.4350*eoc
+		g520 = -532.114 + 3017.977*em - 5740.032*emsq + 3708.2760*eoc
+	} else {
+		g211 = -72.099 + 331.819*em - 508.738*emsq + 266.724*eoc
+		g310 = -346.844 + 1582.851*em - 2415.925*emsq + 1246.113*eoc
+		g322 = -342.585 + 1554.908*em - 2366.899*emsq + 1215.972*eoc
+		g410 = -1052.797 + 4758.686*em - 7193.992*emsq + 3651.957*eoc
+		g422 = -3581.690 + 16178.110*em - 24462.770*emsq + 12422.520*eoc
+		if em > 0.715 {
+			g520 = -5149.66 + 29936.92*em - 54087.36*emsq + 31324.56*eoc
+		} else {
+			g520 = 1464.74 - 4664.75*em + 3763.64*emsq
+		}
+	}
+	if em < 0.7 {
+		g533 = -919.22770 + 4988.6100*em - 9064.7700*emsq + 5542.21*eoc
+		g521 = -822.71072 + 4568.6173*em - 8491.4146*emsq + 5337.524*eoc
+		g532 = -853.66600 + 4690.2500*em - 8624.7700*emsq + 5341.4*eoc
+	} else {
+		g533 = -37995.780 + 161616.52*em - 229838.20*emsq + 109377.94*eoc
+		g521 = -51752.104 + 218913.95*em - 309468.16*emsq + 146349.42*eoc
+		g532 = -40023.880 + 170470.89*em - 242699.48*emsq + 115605.82*eoc
+	}
+	cosisq := cosim * cosim
+	sini2 := sinim * sinim
+	f220 := 0.75 * (1 + 2*cosim + cosisq)
+	f221 := 1.5 * sini2
+	f321 := 1.875 * sinim * (1 - 2*cosim - 3*cosisq)
+	f322 := -1.875 * sinim * (1 + 2*cosim - 3*cosisq)
+	f441 := 35 * sini2 * f220
+	f442 := 39.3750 * sini2 * sini2
+	f522 := 9.84375 * sinim * (sini2*(1-2*cosim-5*cosisq) + 0.33333333*(-2+4*cosim+6*cosisq))
+	f523 := sinim * (4.92187512*sini2*(-2-4*cosim+10*cosisq) + 6.56250012*(1+2*cosim-3*cosisq))
+	f542 := 29.53125 * sinim * (2 - 8*cosim + cosisq*(-12+8*cosim+10*cosisq))
+	f543 := 29.53125 * sinim * (-2 - 8*cosim + cosisq*(12+8*cosim-10*cosisq))
+	temp1 := 3 * s.n * s.n * aonv * aonv
+	temp := temp1 * root22
+	ds.d2201 = temp * f220 * g201
+	ds.d2211 = temp * f221 * g211
+	temp1 *= aonv
+	temp = temp1 * root32
+	ds.d3210 = temp * f321 * g310
+	ds.d3222 = temp * f322 * g322
+	temp1 *= aonv
+	temp = 2 * temp1 * root44
+	ds.d4410 = temp * f441 * g410
+	ds.d4422 = temp * f442 * g422
+	temp1 *= aonv
+	temp = temp1 * root52
+	ds.d5220 = temp * f522 * g520
+	ds.d5232 = temp * f523 * g532
+	temp = 2 * temp1 * root54
+	ds.d5421 = temp * f542 * g521
+	ds.d5433 = temp * f543 * g533
+	ds.xlamo = math.Mod(s.m+s.node+s.node-theta-theta, twoPi)
+	ds.xfact = s.mdot + ds.dmdt + 2*(s.nodedot+ds.dnodt-rptim) - s.n
+}
+
+// secular applies the secular effects of the sun and the moon and of
+// resonance to the mean elements of s at t minutes after epoch. It returns
+// them along with the mean motion.
+func (s *Satellite) secular(t, em, argpm, inclm, mm, nodem float64) (float64, float64, float64, float64, float64, float64) {
+	ds := s.ds
+	em += ds.dedt * t
+	inclm += ds.didt * t
+	argpm += ds.domdt * t
+	nodem += ds.dnodt * t
+	mm += ds.dmdt * t
+	if ds.irez == 0 {
+		return em, argpm, inclm, mm, nodem, s.n
+	}
+	const (
+		fasx2 = 0.13130908
+		fasx4 = 2.8843198
+		fasx6 = 0.37448087
+		g22   = 5.7686396
+		g32   = 0.95240898
+		g44   = 1.8014998
+		g52   = 1.0508330
+		g54   = 4.4108898
+		stepp = 720.
+		step2 = stepp * stepp / 2
+	)
+	// Integrate the resonance terms from epoch in steps of 12 hours.
+	delt := stepp
+	if t < 0 {
+		delt = -stepp
+	}
+	xli, xni, atime := ds.xlamo, s.n, 0.
+	var xndt, xldot, xnddt float64
+	for {
+		if ds.irez == 1 {
+			xndt = ds.del1*math.Sin(xli-fasx2) + ds.del2*math.Sin(2*(xli-fasx4)) + ds.del3*math.Sin(3*(xli-fasx6))
+			xnddt = ds.del1*math.Cos(xli-fasx2) + 2*ds.del2*math.Cos(2*(xli-fasx4)) + 3*ds.del3*math.Cos(3*(xli-fasx6))
+		} else {
+			xomi := s.peri + s.argpdot*atime
+			x2omi := xomi + xomi
+			x2li := xli + xli
+			xndt = ds.d2201*math.Sin(x2omi+xli-g22) + ds.d2211*math.Sin(xli-g22) +
+				ds.d3210*math.Sin(xomi+xli-g32) + ds.d3222*math.Sin(-xomi+xli-g32) +
+				ds.d4410*math.Sin(x2omi+x2li-g44) + ds.d4422*math.Sin(x2li-g44) +
+				ds.d5220*math.Sin(xomi+xli-g52) + ds.d5232*math.Sin(-xomi+xli-g52) +
+				ds.d5421*math.Sin(xomi+x2li-g54) + ds.d5433*math.Sin(-xomi+x2li-g54)
+			xnddt = ds.d2201*math.Cos(x2omi+xli-g22) + ds.d2211*math.Cos(xli-g22) +
+				ds.d3210*math.Cos(xomi+xli-g32) + ds.d3222*math.Cos(-xomi+xli-g32) +
+				ds.d5220*math.Cos(xomi+xli-g52) + ds.d5232*math.Cos(-xomi+xli-g52) +
+				2*(ds.d4410*math.Cos(x2omi+x2li-g44)+ds.d4422*math.Cos(x2li-g44)+
+					ds.d5421*math.Cos(xomi+x2li-g54)+ds.d5433*math.Cos(-xomi+x2li-g54))
+		}
+		xldot = xni + ds.xfact
+		xnddt *= xldot
+		if math.Abs(t-atime) < stepp {
+			break
+		}
+		xli += xldot*delt + xndt*step2
+		xni += xndt*delt + xnddt*step2
+		atime += delt
+	}
+	ft := t - atime
+	nm := xni + xndt*ft + xnddt*ft*ft*0.5
+	xl := xli + xldot*ft + xndt*ft*ft*0.5
+	theta := math.Mod(ds.gsto+t*rptim, twoPi)
+	if ds.irez == 1 {
+		mm = xl - nodem - argpm + theta
+	} else {
+		mm = xl - 2*nodem + 2*theta
+	}
+	return em, argpm, inclm, mm, nodem, nm
+}
+
+// periodics applies the long-period periodic effects of the sun and the
+// moon to the elements of s at t minutes after epoch.
+func (s *Satellite) periodics(t, ep, inclp, nodep, argpp, mp float64) (float64, float64, float64, float64, float64) {
+	ds := s.ds
+	terms := func(zm, ze float64) (f2, f3, sinzf float64) {
+		zf := zm + 2*ze*math.Sin(zm)
+		sinzf = math.Sin(zf)
+		return 0.5*sinzf*sinzf - 0.25, -0.5 * sinzf * math.Cos(zf), sinzf
+	}
+	f2, f3, sinzf := terms(ds.zmos+zns*t, zes)
+	ses := ds.se2*f2 + ds.se3*f3
+	sis := ds.si2*f2 + ds.si3*f3
+	sls := ds.sl2*f2 + ds.sl3*f3 + ds.sl4*sinzf
+	sghs := ds.sgh2*f2 + ds.sgh3*f3 + ds.sgh4*sinzf
+	shs := ds.sh2*f2 + ds.sh3*f3
+	f2, f3, sinzf = terms(ds.zmol+znl*t, zel)
+	sel := ds.ee2*f2 + ds.e3*f3
+	sil := ds.xi2*f2 + ds.xi3*f3
+	sll := ds.xl2*f2 + ds.xl3*f3 + ds.xl4*sinzf
+	sghl := ds.xgh2*f2 + ds.xgh3*f3 + ds.xgh4*sinzf
+	shll := ds.xh2*f2 + ds.xh3*f3
+	pe := ses + sel
+	pinc := sis + sil
+	pl := sls + sll
+	pgh := sghs + sghl
+	ph := shs + shll
+	inclp += pinc
+	ep += pe
+	sinip, cosip := math.Sincos(inclp)
+	if inclp >= 0.2 {
+		ph /= sinip
+		argpp += pgh - cosip*ph
+		nodep += ph
+		mp += pl
+		return ep, inclp, nodep, argpp, mp
+	}
+	// Apply the periodics to the node with Lyddane's modification, which
+	// avoids the singularity at zero inclination.
+	sinop, cosop := math.Sincos(nodep)
+	alfdp := sinip*sinop + ph*cosop + pinc*cosip*sinop
+	betdp := sinip*cosop - ph*sinop + pinc*cosip*cosop
+	nodep = math.Mod(nodep, twoPi)
+	xls := mp + argpp + cosip*nodep + pl + pgh - pinc*nodep*sinip
+	xnoh := nodep
+	nodep = math.Atan2(alfdp, betdp)
+	if math.Abs(xnoh-nodep) > math.Pi {
+		if nodep < xnoh {
+			nodep += twoPi
+		} else {
+			nodep -= twoPi
+		}
+	}
+	mp += pl
+	argpp = xls - mp - cosip*nodep
+	return ep, inclp, nodep, argpp, mp
+}
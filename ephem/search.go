@@ -0,0 +1,973 @@
+// Copyright 2024 Matthew P. Dargan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ephem
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+func (c *state) search(stars StarReader) error {
+	for i, o := range c.objs {
+		if o.name == c.oShad.name {
+			continue
+		}
+		t := c.rise(o, -0.833)
+		var flag Flag
+		if i == 0 {
+			flag = Timed
+		} else {
+			flag = Timed | Dark
+		}
+		if t >= 0 {
+			err := c.event(evt{kind: Rise, bodies: []string{o.name}, s: fmt.Sprintf("%s rises", o.fname), tim: t, flag: flag})
+			if err != nil {
+				return err
+			}
+		}
+		t = c.set(o, -0.833)
+		if t >= 0 {
+			err := c.event(evt{kind: Set, bodies: []string{o.name}, s: fmt.Sprintf("%s sets", o.fname), tim: t, flag: flag})
+			if err != nil {
+				return err
+			}
+		}
+		// The sun's transit is reported even when it is below the horizon
+		// since it marks local noon.
+		t = c.transit(o, 0)
+		if t >= 0 {
+			if el := c.at(o, t).El; i == 0 || el > -0.833 {
+				err := c.event(evt{kind: Transit, bodies: []string{o.name}, s: fmt.Sprintf("%s transits at altitude %d°", o.fname, int(math.Round(el))), tim: t, flag: flag})
+				if err != nil {
+					return err
+				}
+			}
+		}
+		t = c.transit(o, math.Pi)
+		if t >= 0 {
+			if el := c.at(o, t).El; el > -0.833 {
+				err := c.event(evt{kind: LowerTransit, bodies: []string{o.name}, s: fmt.Sprintf("%s transits below the pole at altitude %d°", o.fname, int(math.Round(el))), tim: t, flag: flag})
+				if err != nil {
+					return err
+				}
+			}
+		}
+		if o.name == c.oSun.name {
+			for j := range 4 {
+				t = c.solstice(j)
+				if t >= 0 {
+					err := c.event(evt{kind: Season, bodies: []string{o.name}, s: solstr[j], tim: t, flag: Signif | Timed})
+					if err != nil {
+						return err
+					}
+				}
+			}
+			for i := range c.showers {
+				sh := &c.showers[i]
+				t = c.showerPeak(sh)
+				if t >= 0 {
+					err := c.event(evt{kind: MeteorShower, s: fmt.Sprintf("Peak of the %s (%s), ZHR %.0f", sh.Name, sh.Code, sh.ZHR), tim: t, flag: Signif})
+					if err != nil {
+						return err
+					}
+				}
+			}
+			twitab := []struct {
+				twilight Twilight
+				kind     Kind
+				el       float64
+				up       bool
+				s        string
+			}{
+				{Astronomical, TwilightStart, -18, true, "Twilight starts"},
+				{Astronomical, TwilightEnd, -18, false, "Twilight ends"},
+				{Nautical, NauticalStart, -12, true, "Nautical twilight starts"},
+				{Nautical, NauticalEnd, -12, false, "Nautical twilight ends"},
+				{Civil, CivilStart, -6, true, "Civil twilight starts"},
+				{Civil, CivilEnd, -6, false, "Civil twilight ends"},
+				{BlueHour, BlueHourStart, -6, true, "Blue hour begins"},
+				{BlueHour, BlueHourEnd, -4, true, "Blue hour ends"},
+				{GoldenHour, GoldenHourStart, -4, true, "Golden hour begins"},
+				{GoldenHour, GoldenHourEnd, 6, true, "Golden hour ends"},
+				{GoldenHour, GoldenHourStart, 6, false, "Golden hour begins"},
+				{GoldenHour, GoldenHourEnd, -4, false, "Golden hour ends"},
+				{BlueHour, BlueHourStart, -4, false, "Blue hour begins"},
+				{BlueHour, BlueHourEnd, -6, false, "Blue hour ends"},
+			}
+			for _, tw := range twitab {
+				if c.twilight&tw.twilight == 0 {
+					continue
+				}
+				t = c.elCross(o, tw.el, tw.up)
+				if t >= 0 {
+					err := c.event(evt{kind: tw.kind, bodies: []string{o.name}, s: tw.s, tim: t, flag: Timed})
+					if err != nil {
+						return err
+					}
+				}
+			}
+		}
+		if o.name == c.oMoon.name {
+			if err := c.phases(o); err != nil {
+				return err
+			}
+		}
+		if o.name == c.oMoon.name {
+			t = c.apsis(o, false)
+			if t >= 0 {
+				err := c.event(evt{kind: Perigee, bodies: []string{o.name}, s: fmt.Sprintf("Lunar perigee (%.0f km)", c.at(o, t).Dist*auKm), tim: t, flag: Timed})
+				if err != nil {
+					return err
+				}
+			}
+			t = c.apsis(o, true)
+			if t >= 0 {
+				err := c.event(evt{kind: Apogee, bodies: []string{o.name}, s: fmt.Sprintf("Lunar apogee (%.0f km)", c.at(o, t).Dist*auKm), tim: t, flag: Timed})
+				if err != nil {
+					return err
+				}
+			}
+		}
+		if o.outer {
+			for _, retro := range []bool{true, false} {
+				t = c.station(o, 0, numPoints, retro)
+				if t < 0 {
+					continue
+				}
+				e := evt{kind: StationRetrograde, bodies: []string{o.name}, tim: t, flag: Signif | Timed}
+				dir := "retrograde"
+				if !retro {
+					e.kind = StationDirect
+					dir = "direct"
+				}
+				lon := c.lonAt(o, t)
+				e.s = fmt.Sprintf("%s turns %s at %.1f° in %s", o.fname, dir, math.Mod(lon/radian+360, 360), c.constellation(lon))
+				if err := c.event(e); err != nil {
+					return err
+				}
+			}
+		}
+		if o.outer || o.name == c.oMerc.name || o.name == c.oVenus.name {
+			if err := c.aspects(o); err != nil {
+				return err
+			}
+		}
+		for _, p := range c.objs[i+1:] {
+			if o.name == c.oMoon.name || p.name == c.oMoon.name {
+				if err := c.occult(*o, *p); err != nil {
+					return err
+				}
+				if c.occ.t3 < 0 {
+					continue
+				}
+				if o.name == c.oSun.name || p.name == c.oShad.name {
+					if c.occ.t1 >= 0 {
+						err := c.event(evt{kind: PartialEclipseStart, bodies: []string{o.name, p.name}, s: fmt.Sprintf("Partial eclipse of %s begins", strings.ToLower(o.fname)), tim: c.occ.t1, flag: Signif | Timed})
+						if err != nil {
+							return err
+						}
+					}
+					if c.occ.t2 >= 0 {
+						err := c.event(evt{kind: TotalEclipseStart, bodies: []string{o.name, p.name}, s: fmt.Sprintf("Total eclipse of %s begins", strings.ToLower(o.fname)), tim: c.occ.t2, flag: Signif | Timed})
+						if err != nil {
+							return err
+						}
+					}
+					if c.occ.t4 >= 0 {
+						err := c.event(evt{kind: TotalEclipseEnd, bodies: []string{o.name, p.name}, s: fmt.Sprintf("Total eclipse of %s ends", strings.ToLower(o.fname)), tim: c.occ.t4, flag: Signif | Timed})
+						if err != nil {
+							return err
+						}
+					}
+					if c.occ.t5 >= 0 {
+						err := c.event(evt{kind: PartialEclipseEnd, bodies: []string{o.name, p.name}, s: fmt.Sprintf("Partial eclipse of %s ends", strings.ToLower(o.fname)), tim: c.occ.t5, flag: Signif | Timed})
+						if err != nil {
+							return err
+						}
+					}
+				} else {
+					if c.occ.t1 >= 0 {
+						err := c.event(evt{kind: OccultationStart, bodies: []string{o.name, p.name}, s: fmt.Sprintf("Occultation of %s begins", p.fname), tim: c.occ.t1, flag: Signif | Timed})
+						if err != nil {
+							return err
+						}
+					}
+					if c.occ.t5 >= 0 {
+						err := c.event(evt{kind: OccultationEnd, bodies: []string{o.name, p.name}, s: fmt.Sprintf("Occultation of %s ends", p.fname), tim: c.occ.t5, flag: Signif | Timed})
+						if err != nil {
+							return err
+						}
+					}
+				}
+				continue
+			}
+			if o.name == c.oSun.name {
+				if p.name != c.oMerc.name && p.name != c.oVenus.name {
+					continue
+				}
+				if err := c.occult(*o, *p); err != nil {
+					return err
+				}
+				if c.occ.t3 >= 0 {
+					if c.occ.t1 >= 0 {
+						err := c.event(evt{kind: SolarTransitStart, bodies: []string{o.name, p.name}, s: fmt.Sprintf("Transit of %s begins", p.fname), tim: c.occ.t1, flag: Signif | Light | Timed})
+						if err != nil {
+							return err
+						}
+					}
+					if c.occ.t5 >= 0 {
+						err := c.event(evt{kind: SolarTransitEnd, bodies: []string{o.name, p.name}, s: fmt.Sprintf("Transit of %s ends", p.fname), tim: c.occ.t5, flag: Signif | Light | Timed})
+						if err != nil {
+							return err
+						}
+					}
+				}
+				continue
+			}
+			t = dist(o.point[0], p.point[0])
+			if t > 5000 {
+				continue
+			}
+			err := c.event(evt{kind: Conjunction, bodies: []string{o.name, p.name}, s: fmt.Sprintf("%s is in the house of %s", o.fname, p.fname)})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if c.jupiter {
+		if err := c.jovian(c.find("jupiter")); err != nil {
+			return err
+		}
+	}
+	if stars != nil {
+		if err := c.readStars(stars); err != nil {
+			return err
+		}
+	}
+	return c.catalog(stars == nil && c.occultMode)
+}
+
+// maxElRate bounds the rate at which the elevation of any object changes, in
+// degrees per step.
+const maxElRate = 16 * 24 * stepSize
+
+// at returns the position of o at x steps after the start of the search.
+func (c *state) at(o *obj2, x float64) Coord {
+	c.seTime(c.day + x*stepSize)
+	o.f(c)
+	var p Coord
+	c.obj(&p)
+	return p
+}
+
+func (c *state) rise(o *obj2, el float64) float64 {
+	return c.elCross(o, el, true)
+}
+
+func (c *state) set(o *obj2, el float64) float64 {
+	return c.elCross(o, el, false)
+}
+
+// elCross returns the first time, in steps, at which o crosses elevation el
+// going up if up is true and going down otherwise. It returns -1 if o does not
+// cross el during the search.
+func (c *state) elCross(o *obj2, el float64, up bool) float64 {
+	f := func(x float64) float64 {
+		return c.at(o, x).El - el
+	}
+	for i := 1; i < len(o.point); i++ {
+		t := c.elScan(f, float64(i-1), float64(i), o.point[i-1].El-el, o.point[i].El-el, up)
+		if t >= 0 {
+			return t
+		}
+	}
+	return -1
+}
+
+// elScan looks for a crossing of zero by f in [a, b]. Between two samples an
+// object can dip below and rise back above its elevation only if it has time
+// to travel there and back, so intervals where that is possible are split
+// until the crossing is found or ruled out.
+func (c *state) elScan(f func(float64) float64, a, b, fa, fb float64, up bool) float64 {
+	if up && fa <= 0 && fb > 0 || !up && fa > 0 && fb <= 0 {
+		return c.root(f, a, b, fa, fb)
+	}
+	if b-a <= c.tol || math.Abs(fa)+math.Abs(fb) > maxElRate*(b-a) {
+		return -1
+	}
+	m := (a + b) / 2
+	fm := f(m)
+	if t := c.elScan(f, a, m, fa, fm, up); t >= 0 {
+		return t
+	}
+	return c.elScan(f, m, b, fm, fb, up)
+}
+
+// root returns the zero of f in [a, b], where f(a) = fa and f(b) = fb have
+// opposite signs. It takes secant steps, falling back to bisection when they
+// do not halve the interval, until the interval is within the tolerance.
+func (c *state) root(f func(float64) float64, a, b, fa, fb float64) float64 {
+	bisect := false
+	for b-a > c.tol {
+		w := b - a
+		x := (a + b) / 2
+		if !bisect {
+			x = a - fa*(b-a)/(fb-fa)
+		}
+		fx := f(x)
+		if (fx > 0) == (fa > 0) {
+			a, fa = x, fx
+		} else {
+			b, fb = x, fx
+		}
+		bisect = b-a > w/2
+	}
+	return a - fa*(b-a)/(fb-fa)
+}
+
+// maximum returns the point in [a, b] where f is greatest, found by golden
+// section search to within the tolerance.
+func (c *state) maximum(f func(float64) float64, a, b float64) float64 {
+	g := (math.Sqrt(5) - 1) / 2
+	x1 := b - g*(b-a)
+	x2 := a + g*(b-a)
+	f1, f2 := f(x1), f(x2)
+	for b-a > c.tol {
+		if f1 < f2 {
+			a, x1, f1 = x1, x2, f2
+			x2 = a + g*(b-a)
+			f2 = f(x2)
+		} else {
+			b, x2, f2 = x2, x1, f1
+			x1 = b - g*(b-a)
+			f1 = f(x1)
+		}
+	}
+	return (a + b) / 2
+}
+
+var solLong = [...]float64{math.Pi, -math.Pi / 2, 0, math.Pi / 2}
+
+// solstice returns the time, in steps, of the equinox or solstice named by
+// solstr[n], or -1 if it does not happen during the search.
+func (c *state) solstice(n int) float64 {
+	return c.sunLong(solLong[n])
+}
+
+// sunLong returns the time, in steps, at which the apparent longitude of the
+// sun reaches lon, or -1.
+func (c *state) sunLong(lon float64) float64 {
+	return c.angleCross(&c.oSun, func(p Coord) float64 { return p.Lon }, lon)
+}
+
+// transit returns the time, in steps, at which the local hour angle of o
+// reaches ha, or -1. An hour angle of 0 is the upper culmination and one of
+// π the lower.
+func (c *state) transit(o *obj2, ha float64) float64 {
+	return c.angleCross(o, func(p Coord) float64 { return p.HA }, ha)
+}
+
+// angleCross returns the time, in steps, at which the angle v of the
+// position of o increases through a, or -1.
+func (c *state) angleCross(o *obj2, v func(Coord) float64, a float64) float64 {
+	f := func(x float64) float64 {
+		return math.Remainder(v(c.at(o, x))-a, twoPi)
+	}
+	for i := 1; i <= numPoints; i++ {
+		d1 := math.Remainder(v(o.point[i-1])-a, twoPi)
+		d2 := math.Remainder(v(o.point[i])-a, twoPi)
+		if d1 < 0 && d2 >= 0 && d2-d1 < math.Pi {
+			return c.root(f, float64(i-1), float64(i), d1, d2)
+		}
+	}
+	return -1
+}
+
+// Least distance of the moon at perigee and greatest at apogee in km.
+const (
+	perigeeKm = 356400
+	apogeeKm  = 406700
+)
+
+// apsis returns the time, in steps, at which the distance of o is greatest
+// if far is true and least otherwise, or -1 if it is not reached during the
+// search.
+func (c *state) apsis(o *obj2, far bool) float64 {
+	s := 1.
+	if !far {
+		s = -1
+	}
+	v := make([]float64, len(o.point))
+	for i, p := range o.point {
+		v[i] = s * p.Dist
+	}
+	return c.peak(func(x float64) float64 { return s * c.at(o, x).Dist }, v)
+}
+
+// geoAt returns the geocentric right ascension and declination of o at x
+// steps after the start of the search.
+func (c *state) geoAt(o *obj2, x float64) Coord {
+	c.seTime(c.day + x*stepSize)
+	o.f(c)
+	return Coord{RA: c.alpha, Decl: c.delta}
+}
+
+// elong returns the elongation of o from the sun in degrees at x steps after
+// the start of the search. It is geocentric: the parallax of the observer
+// would add a daily wobble larger than the change in elongation near its
+// extremes.
+func (c *state) elong(o *obj2, x float64) float64 {
+	s := c.geoAt(&c.oSun, x)
+	return dist(c.geoAt(o, x), s) / 3600
+}
+
+// aspects reports the configurations of the planet o with respect to the
+// sun: conjunctions, oppositions, quadratures, and greatest elongations.
+func (c *state) aspects(o *obj2) error {
+	inner := o.name == c.oMerc.name || o.name == c.oVenus.name
+	e := make([]float64, len(o.point))
+	neg := make([]float64, len(o.point))
+	for i := range e {
+		e[i] = c.elong(o, float64(i))
+		neg[i] = -e[i]
+	}
+	t := c.peak(func(x float64) float64 { return -c.elong(o, x) }, neg)
+	if t >= 0 {
+		ev := evt{kind: SuperiorConjunction, bodies: []string{o.name, c.oSun.name}, s: fmt.Sprintf("Superior conjunction of %s", o.fname), tim: t, flag: Timed}
+		if c.at(o, t).Dist < c.at(&c.oSun, t).Dist {
+			ev.kind = InferiorConjunction
+			ev.s = fmt.Sprintf("Inferior conjunction of %s", o.fname)
+		}
+		if err := c.event(ev); err != nil {
+			return err
+		}
+	}
+	t = c.peak(func(x float64) float64 { return c.elong(o, x) }, e)
+	if t >= 0 {
+		var ev evt
+		if inner {
+			side := "morning"
+			if c.east(o, t) {
+				side = "evening"
+			}
+			ev = evt{kind: Elongation, s: fmt.Sprintf("Greatest %s elongation of %s (%.1f°)", side, o.fname, c.elong(o, t))}
+		} else {
+			ev = evt{kind: Opposition, s: fmt.Sprintf("%s at opposition (%.1f°)", o.fname, c.elong(o, t))}
+		}
+		ev.bodies = []string{o.name, c.oSun.name}
+		ev.tim = t
+		ev.flag = Signif | Timed
+		if err := c.event(ev); err != nil {
+			return err
+		}
+	}
+	if inner {
+		return nil
+	}
+	// Quadrature is when the elongation passes through 90°.
+	for i := 1; i <= numPoints; i++ {
+		d1, d2 := e[i-1]-90, e[i]-90
+		if (d1 < 0) == (d2 < 0) {
+			continue
+		}
+		t = c.root(func(x float64) float64 { return c.elong(o, x) - 90 }, float64(i-1), float64(i), d1, d2)
+		side := "western"
+		if c.east(o, t) {
+			side = "eastern"
+		}
+		return c.event(evt{kind: Quadrature, bodies: []string{o.name, c.oSun.name}, s: fmt.Sprintf("%s at %s quadrature", o.fname, side), tim: t, flag: Timed})
+	}
+	return nil
+}
+
+// east reports whether o is east of the sun at x steps after the start of
+// the search.
+func (c *state) east(o *obj2, x float64) bool {
+	s := c.geoAt(&c.oSun, x)
+	return math.Remainder(c.geoAt(o, x).RA-s.RA, twoPi) > 0
+}
+
+// phaseNames are the names of the principal phases of the moon, when its
+// elongation in longitude from the sun is 0°, 90°, 180°, and 270°.
+var phaseNames = [4]string{"New moon", "First quarter moon", "Full moon", "Last quarter moon"}
+
+// quarter returns the quarter of the synodic month, 0 to 3, in which the
+// elongation in longitude of the moon from the sun is e.
+func quarter(e float64) int {
+	return int(math.Mod(e+2*twoPi, twoPi)/(math.Pi/2)) % 4
+}
+
+// phases reports the principal phases of the moon o during the search, and
+// whether a full moon is a supermoon or a micromoon.
+func (c *state) phases(o *obj2) error {
+	for j := range len(o.point) - 2 {
+		q := quarter(o.point[j+1].Lon - c.oSun.point[j+1].Lon)
+		if q == quarter(o.point[j].Lon-c.oSun.point[j].Lon) {
+			continue
+		}
+		t := c.phaseTime(q, float64(j), float64(j+1))
+		err := c.event(evt{kind: MoonPhase, bodies: []string{o.name}, s: phaseNames[q], tim: t, flag: Timed})
+		if err != nil {
+			return err
+		}
+		if q != 2 {
+			continue
+		}
+		d := c.at(o, t).Dist * auKm
+		switch {
+		case d < perigeeKm+c.supermoon:
+			err = c.event(evt{kind: Supermoon, bodies: []string{o.name}, s: fmt.Sprintf("Supermoon (%.0f km)", d), tim: t})
+		case d > apogeeKm-c.supermoon:
+			err = c.event(evt{kind: Micromoon, bodies: []string{o.name}, s: fmt.Sprintf("Micromoon (%.0f km)", d), tim: t})
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moonElong returns the elongation in longitude of the moon from the sun x
+// steps after the start of the search.
+func (c *state) moonElong(x float64) float64 {
+	m := c.lonAt(&c.oMoon, x)
+	return m - c.lonAt(&c.oSun, x)
+}
+
+// phaseTime returns the time, in steps between a and b, at which the moon
+// reaches the start of quarter q.
+func (c *state) phaseTime(q int, a, b float64) float64 {
+	f := func(x float64) float64 {
+		return math.Remainder(c.moonElong(x)-float64(q)*math.Pi/2, twoPi)
+	}
+	return c.root(f, a, b, f(a), f(b))
+}
+
+// lonAt returns the apparent geocentric ecliptic longitude of o at x steps
+// after the start of the search.
+func (c *state) lonAt(o *obj2, x float64) float64 {
+	c.seTime(c.day + x*stepSize)
+	o.f(c)
+	return c.lmb2
+}
+
+// lonRate returns the change in the longitude of o over the step centered
+// x steps after the start of the search.
+func (c *state) lonRate(o *obj2, x float64) float64 {
+	return math.Remainder(c.lonAt(o, x+0.5)-c.lonAt(o, x-0.5), twoPi)
+}
+
+// station returns the first time, in steps between a and b, at which o
+// stops moving eastward if retro is true, or westward otherwise, or -1.
+// The rate is sampled once a step during a search and once a day over
+// longer spans.
+func (c *state) station(o *obj2, a, b float64, retro bool) float64 {
+	step := 1.
+	if b-a > numPoints {
+		step = numPoints
+	}
+	s := 1.
+	if !retro {
+		s = -1
+	}
+	f := func(x float64) float64 {
+		return -s * c.lonRate(o, x)
+	}
+	f1 := f(a)
+	for x := a + step; x <= b; x += step {
+		f2 := f(x)
+		if f1 < 0 && f2 >= 0 {
+			return c.root(f, x-step, x, f1, f2)
+		}
+		f1 = f2
+	}
+	return -1
+}
+
+// constellation returns the name of the zodiacal constellation at the
+// apparent ecliptic longitude lon of date. It follows the ecliptic, so an
+// object far from it, such as Pluto, may lie in a neighboring constellation.
+func (c *state) constellation(lon float64) string {
+	// Precess back to J2000.
+	lon = math.Mod(lon/radian-1.396971*(c.capt-1)+720, 360)
+	name := zodiac[len(zodiac)-1].name
+	for _, z := range zodiac {
+		if lon < z.lon {
+			break
+		}
+		name = z.name
+	}
+	return name
+}
+
+// peak returns the time, in steps, of the first local maximum of f, whose
+// values at the points of the search are v, or -1 if there is none.
+func (c *state) peak(f func(float64) float64, v []float64) float64 {
+	for i := 2; i < len(v); i++ {
+		if v[i-1] >= v[i-2] && v[i-1] > v[i] {
+			return c.maximum(f, float64(i-2), float64(i))
+		}
+	}
+	return -1
+}
+
+func (c *state) event(e evt) error {
+	if e.flag&Dark > 0 && c.sunEl(e.tim) > -12 {
+		return nil
+	}
+	if e.flag&Light > 0 && c.sunEl(e.tim) < 0 {
+		return nil
+	}
+	// Allow for the events of comets beyond the first.
+	if len(c.events) >= 100+10*(len(c.objs)-12) {
+		return errors.New("too many events")
+	}
+	c.events = append(c.events, e)
+	return nil
+}
+
+func (c *state) sunEl(t float64) float64 {
+	i := int(t)
+	if i < 0 || i > numPoints {
+		return -90
+	}
+	return c.oSun.point[i].El + (t-float64(i))*(c.oSun.point[i+1].El-c.oSun.point[i].El)
+}
+
+func (c *state) occult(o1, o2 obj2) error {
+	c.occ.t1 = -100
+	c.occ.t2 = -100
+	c.occ.t3 = -100
+	c.occ.t4 = -100
+	c.occ.t5 = -100
+	var i int
+	var d1, d2, d3 float64
+	var ok bool
+	for i = 2; i < len(o1.point); i++ {
+		d1 = dist(o1.point[i-2], o2.point[i-2])
+		d2 = dist(o1.point[i-1], o2.point[i-1])
+		d3 = dist(o1.point[i], o2.point[i])
+		if d2 <= d1 && d2 <= d3 {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return nil
+	}
+	ok = false
+	n := 2880 * iVal / numPoints // 1 min steps.
+	i -= 2
+	pts(o1, i, &c.occ1)
+	pts(o2, i, &c.occ2)
+	di := float64(i)
+	x := 0.
+	dx := 2 / n
+	for i = range int(n + 1) {
+		pt(&c.occ1, x)
+		pt(&c.occ2, x)
+		d1, d2 = d2, d3
+		d3 = dist(c.occ1.act, c.occ2.act)
+		if i >= 2 && d2 <= d1 && d2 <= d3 {
+			ok = true
+			break
+		}
+		x += dx
+	}
+	if !ok {
+		return fmt.Errorf("ephem: occultation search did not converge for %s and %s", o1.name, o2.name)
+	}
+	ok = false
+	if d2 > c.occ1.act.Semi+c.occ2.act.Semi+50 {
+		return nil
+	}
+	di += x - 3*dx
+	x = 0
+	var xo1, xo2 obj2
+	for i = range 3 {
+		c.seTime(c.day + stepSize*(di+x))
+		o1.f(c)
+		c.obj(&xo1.point[i])
+		o2.f(c)
+		c.obj(&xo2.point[i])
+		x += 2 * dx
+	}
+	dx /= 60
+	x = 0
+	pts(xo1, 0, &c.occ1)
+	pts(xo2, 0, &c.occ2)
+	for i = range 241 {
+		pt(&c.occ1, x)
+		pt(&c.occ2, x)
+		d1, d2 = d2, d3
+		d3 = dist(c.occ1.act, c.occ2.act)
+		if i >= 2 && d2 <= d1 && d2 <= d3 {
+			ok = true
+			break
+		}
+		x += 1. / 120
+	}
+	if !ok {
+		return fmt.Errorf("ephem: occultation search did not reach the least distance of %s and %s", o1.name, o2.name)
+	}
+	if d2 > c.occ1.act.Semi+c.occ2.act.Semi {
+		return nil
+	}
+	i1 := i - 1
+	x1 := x - 1./120
+	c.occ.t3 = di + float64(i1)*dx
+	c.occ.e3 = c.occ1.act.El
+	d3 = c.occ1.act.Semi - c.occ2.act.Semi
+	if d3 < 0 {
+		d3 = -d3
+	}
+	d4 := c.occ1.act.Semi + c.occ2.act.Semi
+	i = i1
+	x = x1
+	for {
+		pt(&c.occ1, x)
+		pt(&c.occ2, x)
+		d1 = d2
+		d2 = dist(c.occ1.act, c.occ2.act)
+		if i != i1 {
+			if d1 <= d3 && d2 > d3 {
+				c.occ.t4 = di + (float64(i)-0.5)*dx
+				c.occ.e4 = c.occ1.act.El
+			}
+			if d2 > d4 {
+				if d1 <= d4 {
+					c.occ.t5 = di + (float64(i)-0.5)*dx
+					c.occ.e5 = c.occ1.act.El
+				}
+				break
+			}
+		}
+		x += 1. / 120
+		i++
+	}
+	i = i1
+	x = x1
+	for {
+		pt(&c.occ1, x)
+		pt(&c.occ2, x)
+		d1 = d2
+		d2 = dist(c.occ1.act, c.occ2.act)
+		if i != i1 {
+			if d1 <= d3 && d2 > d3 {
+				c.occ.t2 = di + (float64(i)-0.5)*dx
+				c.occ.e2 = c.occ1.act.El
+			}
+			if d2 > d4 {
+				if d1 <= d4 {
+					c.occ.t1 = di + (float64(i)-0.5)*dx
+					c.occ.e1 = c.occ1.act.El
+				}
+				break
+			}
+		}
+		x -= 1. / 120
+		i--
+	}
+	return nil
+}
+
+func pts(o obj2, i int, oc *occt) {
+	p1, p2, p3 := o.point[i], o.point[i+1], o.point[i+2]
+	oc.del0.RA = p1.RA
+	oc.del0.Decl = p1.Decl
+	oc.del0.Semi = p1.Semi
+	oc.del0.El = p1.El
+	a := p2.RA - p1.RA
+	oc.del1.RA = piNorm(a)
+	a = p2.Decl - p1.Decl
+	oc.del1.Decl = piNorm(a)
+	oc.del1.Semi = p2.Semi - p1.Semi
+	oc.del1.El = p2.El - p1.El
+	a = p1.RA + p3.RA - 2*p2.RA
+	oc.del2.RA = piNorm(a) / 2
+	a = p1.Decl + p3.Decl - 2*p2.Decl
+	oc.del2.Decl = piNorm(a) / 2
+	oc.del2.Semi = (p1.Semi + p3.Semi - 2*p2.Semi) / 2
+	oc.del2.El = (p1.El + p3.El - 2*p2.El) / 2
+}
+
+func pt(o *occt, x float64) {
+	y := x * (x - 1)
+	o.act.RA = o.del0.RA + x*o.del1.RA + y*o.del2.RA
+	o.act.Decl = o.del0.Decl + x*o.del1.Decl + y*o.del2.Decl
+	o.act.Semi = o.del0.Semi + x*o.del1.Semi + y*o.del2.Semi
+	o.act.El = o.del0.El + x*o.del1.El + y*o.del2.El
+}
+
+// readStars searches the stars read from r for occultations by the moon.
+func (c *state) readStars(r StarReader) error {
+	// Stars are passed over by right ascension at J2000, so allow for
+	// precession since then as well as the motion of the moon.
+	sd := (1000 + 100*math.Abs(c.eday-36525)/365.25) * radsec
+	lomoon := c.oMoon.point[0].RA - sd
+	if lomoon < 0 {
+		lomoon += twoPi
+	}
+	himoon := c.oMoon.point[numPoints+1].RA + sd
+	if himoon > twoPi {
+		himoon -= twoPi
+	}
+	wrap := false
+	if lomoon > himoon {
+		wrap = true
+	}
+	for {
+		s, err := r.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		ra := s.RA * radian
+		if wrap && (ra < lomoon && ra > himoon) || !wrap && (ra < lomoon || ra > himoon) {
+			continue
+		}
+		c.catalogPlace(&s)
+		if _, err := c.occultStar(s.Name, s.Name, s.Mag); err != nil {
+			return err
+		}
+	}
+}
+
+// place sets the apparent place at c.eday of a star whose mean place at
+// epoch is alpha (hours) and delta (degrees), with proper motion da (seconds
+// of time per year) and dd (seconds of arc per year), and distance rad (au).
+func (c *state) place(alpha, delta, da, dd, rad, epoch float64) {
+	// Correct for proper motion.
+	tau := (c.eday - epoch) / 365.2422
+	c.alpha = alpha + tau*da/3600
+	c.delta = delta + tau*dd/3600
+	c.alpha, c.delta = precess(c.alpha*15*radian, c.delta*radian, epoch, c.eday)
+	// Convert to mean ecliptic system of date.
+	cl := math.Cos(c.delta) * math.Cos(c.alpha)
+	sl := math.Cos(c.delta)*math.Sin(c.alpha)*math.Cos(c.obliq) + math.Sin(c.delta)*math.Sin(c.obliq)
+	sb := -math.Cos(c.delta)*math.Sin(c.alpha)*math.Sin(c.obliq) + math.Sin(c.delta)*math.Cos(c.obliq)
+	c.lambda = math.Atan2(sl, cl)
+	c.beta = math.Atan2(sb, math.Sqrt(cl*cl+sl*sl))
+	c.rad = rad
+	c.motion = 0
+	c.semi = 0
+	c.helio()
+	c.geo()
+}
+
+// precess returns the right ascension and declination (radians) at the
+// equinox of day to of a mean place at the equinox of day from.
+func precess(alpha, delta, from, to float64) (float64, float64) {
+	// Convert to rectangular coordinates merely for convenience.
+	xm := math.Cos(delta) * math.Cos(alpha)
+	ym := math.Cos(delta) * math.Sin(alpha)
+	zm := math.Sin(delta)
+	capt0 := (from - 18262.427) / 36524.22e0
+	capt1 := (to - from) / 36524.22
+	capt12 := capt1 * capt1
+	capt13 := capt12 * capt1
+	xx := -(0.00029696+26.e-8*capt0)*capt12 - 13e-8*capt13
+	yx := -(0.02234941+1355.e-8*capt0)*capt1 - 676e-8*capt12 + 221e-8*capt13
+	zx := -(0.0097169-414e-8*capt0)*capt1 + 207e-8*capt12 + 96e-8*capt13
+	yy := -(0.00024975+30e-8*capt0)*capt12 - 15e-8*capt13
+	zy := -(0.00010858 + 2e-8*capt0) * capt12
+	zz := -(0.00004721 - 4e-8*capt0) * capt12
+	dxm := xx*xm + yx*ym + zx*zm
+	dym := -yx*xm + yy*ym + zy*zm
+	dzm := -zx*xm + zy*ym + zz*zm
+	xm += dxm
+	ym += dym
+	zm += dzm
+	return math.Atan2(ym, xm), math.Atan2(zm, math.Sqrt(xm*xm+ym*ym))
+}
+
+// catalogPlace sets the apparent place at c.eday of a star of the embedded
+// catalog.
+func (c *state) catalogPlace(s *Star) {
+	da := s.PMRA / (15000 * math.Cos(s.Dec*radian))
+	c.place(s.RA/15, s.Dec, da, s.PMDec/1000, 1e9, 36525) // J2000
+	c.mag = s.Mag
+}
+
+// occultStar reports the occultation by the moon, if any, of the star whose
+// place was last set by place. It reports whether there is one.
+func (c *state) occultStar(name, fname string, mag float64) (bool, error) {
+	sd := 0.0896833e0*math.Cos(c.beta)*math.Sin(c.lambda-1.382+.00092422117*c.eday) + 0.99597*math.Sin(c.beta)
+	if math.Abs(sd) > 0.0183 {
+		return false, nil
+	}
+	for i := range c.oStar.point {
+		c.obj(&c.oStar.point[i])
+	}
+	if err := c.occult(c.oMoon, c.oStar); err != nil {
+		return false, err
+	}
+	if c.occ.t1 < 0 && c.occ.t5 < 0 {
+		return false, nil
+	}
+	i := Timed
+	if mag > 2 {
+		i |= Dark
+	}
+	if mag < 5 {
+		i |= Signif
+	}
+	if c.occ.t1 >= 0 && c.occ.e1 >= 0 {
+		err := c.event(evt{kind: OccultationStart, bodies: []string{c.oMoon.name, name}, s: fmt.Sprintf("Occultation of %s begins", fname), tim: c.occ.t1, flag: i})
+		if err != nil {
+			return true, err
+		}
+	}
+	if c.occ.t5 >= 0 && c.occ.e5 >= 0 {
+		err := c.event(evt{kind: OccultationEnd, bodies: []string{c.oMoon.name, name}, s: fmt.Sprintf("Occultation of %s ends", fname), tim: c.occ.t5, flag: i})
+		if err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+// catalog searches the embedded star catalog, if c.starConj is set, for
+// conjunctions of the moon and planets with stars of the first magnitude
+// and, if occult is set, for occultations of its stars by the moon.
+func (c *state) catalog(occult bool) error {
+	if !occult && !c.starConj {
+		return nil
+	}
+	for i := range brightStars {
+		s := &brightStars[i]
+		c.catalogPlace(s)
+		b := s.Body()
+		if occult {
+			ok, err := c.occultStar(b.Name, b.FullName, s.Mag)
+			if err != nil {
+				return err
+			}
+			if ok {
+				continue
+			}
+		}
+		if !c.starConj || s.Mag >= 1.5 {
+			continue
+		}
+		var p Coord
+		c.obj(&p)
+		for _, o := range c.objs {
+			if o == &c.oSun || o == &c.oShad || dist(o.point[0], p) > 5000 {
+				continue
+			}
+			err := c.event(evt{kind: Conjunction, bodies: []string{o.name, b.Name}, s: fmt.Sprintf("%s is in the house of %s", o.fname, b.FullName)})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
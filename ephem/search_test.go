@@ -0,0 +1,99 @@
+// Copyright 2024 Matthew P. Dargan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ephem
+
+import (
+	"testing"
+	"time"
+)
+
+var (
+	london  = &Observer{Lat: 51.5074, WLong: 0.1278}
+	newYork = &Observer{Lat: 40.7128, WLong: 74.0060}
+)
+
+type eventTest struct {
+	name string
+	o    *Observer
+	day  time.Time
+	kind Kind
+	body string
+	want time.Time
+}
+
+func utc(year int, month time.Month, day, hour, min int) time.Time {
+	return time.Date(year, month, day, hour, min, 0, 0, time.UTC)
+}
+
+// findEvent returns the first event of the given kind that involves body
+// found by o in the day starting at day.
+func findEvent(t *testing.T, o *Observer, day time.Time, kind Kind, body string) (Event, bool) {
+	t.Helper()
+	es, err := o.Events(day, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range es {
+		if e.Kind == kind && len(e.Bodies) > 0 && e.Bodies[0] == body {
+			return e, true
+		}
+	}
+	return Event{}, false
+}
+
+func checkEvents(t *testing.T, tests []eventTest, tol time.Duration) {
+	t.Helper()
+	for _, tt := range tests {
+		e, ok := findEvent(t, tt.o, tt.day, tt.kind, tt.body)
+		if !ok {
+			t.Errorf("%s: no %s of %s", tt.name, tt.kind, tt.body)
+			continue
+		}
+		if d := e.Time.Sub(tt.want).Abs(); d > tol {
+			t.Errorf("%s: %s of %s at %v, want %v", tt.name, tt.kind, tt.body, e.Time.Format(time.TimeOnly), tt.want.Format(time.TimeOnly))
+		}
+	}
+}
+
+// TestRiseSet checks sunrise and sunset against the times published for
+// London and New York, which are given to the minute.
+func TestRiseSet(t *testing.T) {
+	tests := []eventTest{
+		{"london june sunrise", london, utc(2024, 6, 20, 0, 0), Rise, "sun", utc(2024, 6, 20, 3, 43)},
+		{"london june sunset", london, utc(2024, 6, 20, 0, 0), Set, "sun", utc(2024, 6, 20, 20, 21)},
+		{"london december sunrise", london, utc(2024, 12, 21, 0, 0), Rise, "sun", utc(2024, 12, 21, 8, 4)},
+		{"london december sunset", london, utc(2024, 12, 21, 0, 0), Set, "sun", utc(2024, 12, 21, 15, 53)},
+		{"new york june sunrise", newYork, utc(2024, 6, 20, 5, 0), Rise, "sun", utc(2024, 6, 20, 9, 25)},
+		{"new york june sunset", newYork, utc(2024, 6, 20, 5, 0), Set, "sun", utc(2024, 6, 21, 0, 31)},
+		{"new york december sunrise", newYork, utc(2024, 12, 21, 5, 0), Rise, "sun", utc(2024, 12, 21, 12, 16)},
+		{"new york december sunset", newYork, utc(2024, 12, 21, 5, 0), Set, "sun", utc(2024, 12, 21, 21, 32)},
+	}
+	checkEvents(t, tests, time.Minute)
+}
+
+// TestSeasons checks the equinoxes and solstices of 2024 against the times
+// published by the U.S. Naval Observatory, which are given to the minute.
+// The longitude of the sun from the theory used here runs a few seconds of
+// arc ahead in this decade, which puts the seasons about a minute and a
+// half early, so the tolerance is two minutes.
+func TestSeasons(t *testing.T) {
+	tests := []eventTest{
+		{"spring equinox", london, utc(2024, 3, 20, 0, 0), Season, "sun", utc(2024, 3, 20, 3, 6)},
+		{"summer solstice", london, utc(2024, 6, 20, 0, 0), Season, "sun", utc(2024, 6, 20, 20, 51)},
+		{"fall equinox", london, utc(2024, 9, 22, 0, 0), Season, "sun", utc(2024, 9, 22, 12, 44)},
+		{"winter solstice", london, utc(2024, 12, 21, 0, 0), Season, "sun", utc(2024, 12, 21, 9, 21)},
+	}
+	checkEvents(t, tests, 2*time.Minute)
+}
+
+// TestTransit checks the sun's transit, local noon, at London at the June
+// solstice of 2024 against the published time, which is given to the
+// minute.
+func TestTransit(t *testing.T) {
+	tests := []eventTest{
+		{"london june noon", london, utc(2024, 6, 20, 0, 0), Transit, "sun", utc(2024, 6, 20, 12, 2)},
+	}
+	checkEvents(t, tests, time.Minute)
+}
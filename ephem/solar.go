@@ -0,0 +1,54 @@
+// Copyright 2024 Matthew P. Dargan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ephem
+
+import (
+	"math"
+	"time"
+)
+
+// A SolarDisk is the orientation of the disk of the sun, by which sunspot
+// drawings are oriented.
+type SolarDisk struct {
+	P        float64 // position angle of the northern end of the axis of rotation, east of north (degrees)
+	B0       float64 // heliographic latitude of the center of the disk (degrees)
+	L0       float64 // heliographic (Carrington) longitude of the center of the disk (degrees)
+	Rotation int     // Carrington rotation number
+}
+
+// SolarDisk returns the orientation of the disk of the sun at t.
+func (o *Observer) SolarDisk(t time.Time) SolarDisk {
+	c := newState(o)
+	c.setup(o, t)
+	c.seTime(c.day)
+	c.fSun()
+	return c.solarDisk()
+}
+
+// solarDisk returns the orientation of the disk of the sun from its apparent
+// longitude and the true obliquity, by the method of Carrington as given by
+// Meeus.
+func (c *state) solarDisk() SolarDisk {
+	// Inclination of the solar equator to the ecliptic and longitude of its
+	// ascending node.
+	incl := 7.25 * radian
+	node := (73.6667 + 1.3958333*(c.eday+18262)/36525) * radian
+	// Apparent longitude of the sun, with and without nutation.
+	lmb := c.lmb2 - c.phi
+	x := math.Atan(-math.Cos(c.lmb2) * math.Tan(c.tobliq))
+	y := math.Atan(-math.Cos(lmb-node) * math.Tan(incl))
+	// The prime meridian rotates in the sidereal period of 25.38 days.
+	theta := math.Mod((c.eday+16800)*360/25.38, 360) * radian
+	eta := math.Atan2(-math.Sin(lmb-node)*math.Cos(incl), -math.Cos(lmb-node))
+	d := SolarDisk{
+		P:  (x + y) / radian,
+		B0: math.Asin(math.Sin(lmb-node)*math.Sin(incl)) / radian,
+		L0: math.Mod((eta-theta)/radian+720, 360),
+	}
+	// Rotation 1 began on 9 November 1853; each begins as L0 passes 360°.
+	n := (c.eday + 16879.773) / 27.2752316
+	d.Rotation = int(math.Round(n - (360-d.L0)/360))
+	return d
+}
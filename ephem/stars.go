@@ -0,0 +1,44 @@
+// Copyright 2024 Matthew P. Dargan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ephem
+
+import (
+	"slices"
+	"strings"
+)
+
+// A Star is a star of the catalog embedded in the package.
+type Star struct {
+	Name        string  // proper name, such as "Sirius"
+	Bayer       string  // Bayer or Flamsteed designation, such as "α CMa"
+	RA, Dec     float64 // right ascension and declination at J2000 (degrees)
+	PMRA, PMDec float64 // proper motion in RA·cos Dec and in Dec (mas/yr)
+	Mag         float64 // visual magnitude
+}
+
+// Stars returns the stars of the embedded catalog in order of right
+// ascension. They are brighter than about fourth magnitude, along with the
+// fainter named stars near the ecliptic, such as the Pleiades.
+func Stars() []Star {
+	return slices.Clone(brightStars)
+}
+
+// Body returns the body by which s is named in reports. Its short name is
+// the proper name in lower case.
+func (s Star) Body() Body {
+	return Body{Name: strings.ToLower(s.Name), FullName: s.Name}
+}
+
+// findStar returns the star with the given proper name or designation,
+// ignoring case.
+func findStar(name string) (*Star, bool) {
+	i := slices.IndexFunc(brightStars, func(s Star) bool {
+		return strings.EqualFold(name, s.Name) || strings.EqualFold(name, s.Bayer)
+	})
+	if i < 0 {
+		return nil, false
+	}
+	return &brightStars[i], true
+}
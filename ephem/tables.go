@@ -0,0 +1,1519 @@
+// Copyright 2024 Matthew P. Dargan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ephem
+
+import "time"
+
+var (
+	moonTabs = [...][]moonTab{
+		{
+			{f: 0.127, c: [4]int{0, 0, 0, 6}},
+			{f: 13.902, c: [4]int{0, 0, 0, 4}},
+			{f: 2369.912, c: [4]int{0, 0, 0, 2}},
+			{f: 1.979, c: [4]int{1, 0, 0, 4}},
+			{f: 191.953, c: [4]int{1, 0, 0, 2}},
+			{f: 22639.5, c: [4]int{1, 0, 0, 0}},
+			{f: -4586.465, c: [4]int{1, 0, 0, -2}},
+			{f: -38.428, c: [4]int{1, 0, 0, -4}},
+			{f: -0.393, c: [4]int{1, 0, 0, -6}},
+			{f: -0.289, c: [4]int{0, 1, 0, 4}},
+			{f: -24.42, c: [4]int{0, 1, 0, 2}},
+			{f: -668.146, c: [4]int{0, 1, 0, 0}},
+			{f: -165.145, c: [4]int{0, 1, 0, -2}},
+			{f: -1.877, c: [4]int{0, 1, 0, -4}},
+			{f: 0.403, c: [4]int{0, 0, 0, 3}},
+			{f: -125.154, c: [4]int{0, 0, 0, 1}},
+			{f: 0.213, c: [4]int{2, 0, 0, 4}},
+			{f: 14.387, c: [4]int{2, 0, 0, 2}},
+			{f: 769.016, c: [4]int{2, 0, 0, 0}},
+			{f: -211.656, c: [4]int{2, 0, 0, -2}},
+			{f: -30.773, c: [4]int{2, 0, 0, -4}},
+			{f: -0.57, c: [4]int{2, 0, 0, -6}},
+			{f: -2.921, c: [4]int{1, 1, 0, 2}},
+			{f: -109.673, c: [4]int{1, 1, 0, 0}},
+			{f: -205.962, c: [4]int{1, 1, 0, -2}},
+			{f: -4.391, c: [4]int{1, 1, 0, -4}},
+			{f: -0.072, c: [4]int{1, 1, 0, -6}},
+			{f: 0.283, c: [4]int{1, -1, 0, 4}},
+			{f: 14.577, c: [4]int{1, -1, 0, 2}},
+			{f: 147.687, c: [4]int{1, -1, 0, 0}},
+			{f: 28.475, c: [4]int{1, -1, 0, -2}},
+			{f: 0.636, c: [4]int{1, -1, 0, -4}},
+			{f: -0.189, c: [4]int{0, 2, 0, 2}},
+			{f: -7.486, c: [4]int{0, 2, 0, 0}},
+			{f: -8.096, c: [4]int{0, 2, 0, -2}},
+			{f: -0.151, c: [4]int{0, 2, 0, -4}},
+			{f: -0.085, c: [4]int{0, 0, 2, 4}},
+			{f: -5.741, c: [4]int{0, 0, 2, 2}},
+			{f: -411.608, c: [4]int{0, 0, 2, 0}},
+			{f: -55.173, c: [4]int{0, 0, 2, -2}},
+			{f: -8.466, c: [4]int{1, 0, 0, 1}},
+			{f: 18.609, c: [4]int{1, 0, 0, -1}},
+			{f: 3.215, c: [4]int{1, 0, 0, -3}},
+			{f: 0.15, c: [4]int{0, 1, 0, 3}},
+			{f: 18.023, c: [4]int{0, 1, 0, 1}},
+			{f: 0.56, c: [4]int{0, 1, 0, -1}},
+			{f: 1.06, c: [4]int{3, 0, 0, 2}},
+			{f: 36.124, c: [4]int{3, 0, 0, 0}},
+			{f: -13.193, c: [4]int{3, 0, 0, -2}},
+			{f: -1.187, c: [4]int{3, 0, 0, -4}},
+			{f: -0.293, c: [4]int{3, 0, 0, -6}},
+			{f: -0.29, c: [4]int{2, 1, 0, 2}},
+			{f: -7.649, c: [4]int{2, 1, 0, 0}},
+			{f: -8.627, c: [4]int{2, 1, 0, -2}},
+			{f: -2.74, c: [4]int{2, 1, 0, -4}},
+			{f: -0.091, c: [4]int{2, 1, 0, -6}},
+			{f: 1.181, c: [4]int{2, -1, 0, 2}},
+			{f: 9.703, c: [4]int{2, -1, 0, 0}},
+			{f: -2.494, c: [4]int{2, -1, 0, -2}},
+			{f: 0.36, c: [4]int{2, -1, 0, -4}},
+			{f: -1.167, c: [4]int{1, 2, 0, 0}},
+			{f: -7.412, c: [4]int{1, 2, 0, -2}},
+			{f: -0.311, c: [4]int{1, 2, 0, -4}},
+			{f: 0.757, c: [4]int{1, -2, 0, 2}},
+			{f: 2.58, c: [4]int{1, -2, 0, 0}},
+			{f: 2.533, c: [4]int{1, -2, 0, -2}},
+			{f: -0.103, c: [4]int{0, 3, 0, 0}},
+			{f: -0.344, c: [4]int{0, 3, 0, -2}},
+			{f: -0.992, c: [4]int{1, 0, 2, 2}},
+			{f: -45.099, c: [4]int{1, 0, 2, 0}},
+			{f: -0.179, c: [4]int{1, 0, 2, -2}},
+			{f: -0.301, c: [4]int{1, 0, 2, -4}},
+			{f: -6.382, c: [4]int{1, 0, -2, 2}},
+			{f: 39.528, c: [4]int{1, 0, -2, 0}},
+			{f: 9.366, c: [4]int{1, 0, -2, -2}},
+			{f: 0.202, c: [4]int{1, 0, -2, -4}},
+			{f: 0.415, c: [4]int{0, 1, 2, 0}},
+			{f: -2.152, c: [4]int{0, 1, 2, -2}},
+			{f: -1.44, c: [4]int{0, 1, -2, 2}},
+			{f: 0.076, c: [4]int{0, 1, -2, 0}},
+			{f: 0.384, c: [4]int{0, 1, -2, -2}},
+			{f: -0.586, c: [4]int{2, 0, 0, 1}},
+			{f: 1.75, c: [4]int{2, 0, 0, -1}},
+			{f: 1.225, c: [4]int{2, 0, 0, -3}},
+			{f: 1.267, c: [4]int{1, 1, 0, 1}},
+			{f: 0.137, c: [4]int{1, 1, 0, -1}},
+			{f: 0.233, c: [4]int{1, 1, 0, -3}},
+			{f: -0.122, c: [4]int{1, -1, 0, 1}},
+			{f: -1.089, c: [4]int{1, -1, 0, -1}},
+			{f: -0.276, c: [4]int{1, -1, 0, -3}},
+			{f: 0.255, c: [4]int{0, 0, 2, 1}},
+			{f: 0.584, c: [4]int{0, 0, 2, -1}},
+			{f: 0.254, c: [4]int{0, 0, 2, -3}},
+			{f: 0.07, c: [4]int{4, 0, 0, 2}},
+			{f: 1.938, c: [4]int{4, 0, 0, 0}},
+			{f: -0.952, c: [4]int{4, 0, 0, -2}},
+			{f: -0.551, c: [4]int{3, 1, 0, 0}},
+			{f: -0.482, c: [4]int{3, 1, 0, -2}},
+			{f: -0.1, c: [4]int{3, 1, 0, -4}},
+			{f: 0.088, c: [4]int{3, -1, 0, 2}},
+			{f: 0.681, c: [4]int{3, -1, 0, 0}},
+			{f: -0.183, c: [4]int{3, -1, 0, -2}},
+			{f: -0.297, c: [4]int{2, 2, 0, -2}},
+			{f: -0.161, c: [4]int{2, 2, 0, -4}},
+			{f: 0.197, c: [4]int{2, -2, 0, 0}},
+			{f: 0.254, c: [4]int{2, -2, 0, -2}},
+			{f: -0.25, c: [4]int{1, 3, 0, -2}},
+			{f: -0.123, c: [4]int{2, 0, 2, 2}},
+			{f: -3.996, c: [4]int{2, 0, 2, 0}},
+			{f: 0.557, c: [4]int{2, 0, 2, -2}},
+			{f: -0.459, c: [4]int{2, 0, -2, 2}},
+			{f: -1.37, c: [4]int{2, 0, -2, 0}},
+			{f: 0.538, c: [4]int{2, 0, -2, -2}},
+			{f: 0.173, c: [4]int{2, 0, -2, -4}},
+			{f: 0.263, c: [4]int{1, 1, 2, 0}},
+			{f: 0.083, c: [4]int{1, 1, -2, 2}},
+			{f: -0.083, c: [4]int{1, 1, -2, 0}},
+			{f: 0.426, c: [4]int{1, 1, -2, -2}},
+			{f: -0.304, c: [4]int{1, -1, 2, 0}},
+			{f: -0.372, c: [4]int{1, -1, -2, 2}},
+			{f: 0.083, c: [4]int{1, -1, -2, 0}},
+			{f: 0.418, c: [4]int{0, 0, 4, 0}},
+			{f: 0.074, c: [4]int{0, 0, 4, -2}},
+			{f: 0.13, c: [4]int{3, 0, 0, -1}},
+			{f: 0.092, c: [4]int{2, 1, 0, 1}},
+			{f: 0.084, c: [4]int{2, 1, 0, -3}},
+			{f: -0.352, c: [4]int{2, -1, 0, -1}},
+			{f: 0.113, c: [4]int{5, 0, 0, 0}},
+			{f: -0.33, c: [4]int{3, 0, 2, 0}},
+			{f: 0.09, c: [4]int{1, 0, 4, 0}},
+			{f: -0.08, c: [4]int{1, 0, -4, 0}},
+		},
+		{
+			{f: -112.79, c: [4]int{0, 0, 0, 1}},
+			{f: 2373.36, c: [4]int{0, 0, 0, 2}},
+			{f: -4.01, c: [4]int{0, 0, 0, 3}},
+			{f: 14.06, c: [4]int{0, 0, 0, 4}},
+			{f: 6.98, c: [4]int{1, 0, 0, 4}},
+			{f: 192.72, c: [4]int{1, 0, 0, 2}},
+			{f: -13.51, c: [4]int{1, 0, 0, 1}},
+			{f: 22609.07, c: [4]int{1, 0, 0, 0}},
+			{f: 3.59, c: [4]int{1, 0, 0, -1}},
+			{f: -4578.13, c: [4]int{1, 0, 0, -2}},
+			{f: 5.44, c: [4]int{1, 0, 0, -3}},
+			{f: -38.64, c: [4]int{1, 0, 0, -4}},
+			{f: 14.78, c: [4]int{2, 0, 0, 2}},
+			{f: 767.96, c: [4]int{2, 0, 0, 0}},
+			{f: 2.01, c: [4]int{2, 0, 0, -1}},
+			{f: -152.53, c: [4]int{2, 0, 0, -2}},
+			{f: -34.07, c: [4]int{2, 0, 0, -4}},
+			{f: 2.96, c: [4]int{3, 0, 0, 2}},
+			{f: 50.64, c: [4]int{3, 0, 0, 0}},
+			{f: -16.4, c: [4]int{3, 0, 0, -2}},
+			{f: 3.6, c: [4]int{4, 0, 0, 0}},
+			{f: -1.58, c: [4]int{4, 0, 0, -2}},
+			{f: -1.59, c: [4]int{0, 1, 0, 4}},
+			{f: -25.1, c: [4]int{0, 1, 0, 2}},
+			{f: 17.93, c: [4]int{0, 1, 0, 1}},
+			{f: -126.98, c: [4]int{0, 1, 0, 0}},
+			{f: -165.06, c: [4]int{0, 1, 0, -2}},
+			{f: -6.46, c: [4]int{0, 1, 0, -4}},
+			{f: -1.68, c: [4]int{0, 2, 0, 2}},
+			{f: -16.35, c: [4]int{0, 2, 0, -2}},
+			{f: -11.75, c: [4]int{1, 1, 0, 2}},
+			{f: 1.52, c: [4]int{1, 1, 0, 1}},
+			{f: -115.18, c: [4]int{1, 1, 0, 0}},
+			{f: -182.36, c: [4]int{1, 1, 0, -2}},
+			{f: -9.66, c: [4]int{1, 1, 0, -4}},
+			{f: -2.27, c: [4]int{-1, 1, 0, 4}},
+			{f: -23.59, c: [4]int{-1, 1, 0, 2}},
+			{f: -138.76, c: [4]int{-1, 1, 0, 0}},
+			{f: -31.7, c: [4]int{-1, 1, 0, -2}},
+			{f: -1.53, c: [4]int{-1, 1, 0, -4}},
+			{f: -10.56, c: [4]int{2, 1, 0, 0}},
+			{f: -7.59, c: [4]int{2, 1, 0, -2}},
+			{f: -2.54, c: [4]int{2, 1, 0, -4}},
+			{f: 3.32, c: [4]int{2, -1, 0, 2}},
+			{f: 11.67, c: [4]int{2, -1, 0, 0}},
+			{f: -6.12, c: [4]int{1, 2, 0, -2}},
+			{f: -2.4, c: [4]int{-1, 2, 0, 2}},
+			{f: -2.32, c: [4]int{-1, 2, 0, 0}},
+			{f: -1.82, c: [4]int{-1, 2, 0, -2}},
+			{f: -52.14, c: [4]int{0, 0, 2, -2}},
+			{f: -1.67, c: [4]int{0, 0, 2, -4}},
+			{f: -9.52, c: [4]int{1, 0, 2, -2}},
+			{f: -85.13, c: [4]int{-1, 0, 2, 0}},
+			{f: 3.37, c: [4]int{-1, 0, 2, -2}},
+			{f: -2.26, c: [4]int{0, 1, 2, -2}},
+		},
+		{
+			{f: -0.725, c: [4]int{0, 0, 0, 1}},
+			{f: 0.601, c: [4]int{0, 0, 0, 2}},
+			{f: 0.394, c: [4]int{0, 0, 0, 3}},
+			{f: -0.445, c: [4]int{1, 0, 0, 4}},
+			{f: 0.455, c: [4]int{1, 0, 0, 1}},
+			{f: 0.192, c: [4]int{1, 0, 0, -3}},
+			{f: 5.679, c: [4]int{2, 0, 0, -2}},
+			{f: -0.308, c: [4]int{2, 0, 0, -4}},
+			{f: -0.166, c: [4]int{3, 0, 0, 2}},
+			{f: -1.3, c: [4]int{3, 0, 0, 0}},
+			{f: 0.258, c: [4]int{3, 0, 0, -2}},
+			{f: -1.302, c: [4]int{0, 1, 0, 0}},
+			{f: -0.416, c: [4]int{0, 1, 0, -4}},
+			{f: -0.74, c: [4]int{0, 2, 0, -2}},
+			{f: 0.787, c: [4]int{1, 1, 0, 2}},
+			{f: 0.461, c: [4]int{1, 1, 0, 0}},
+			{f: 2.056, c: [4]int{1, 1, 0, -2}},
+			{f: -0.471, c: [4]int{1, 1, 0, -4}},
+			{f: -0.443, c: [4]int{-1, 1, 0, 2}},
+			{f: 0.679, c: [4]int{-1, 1, 0, 0}},
+			{f: -1.54, c: [4]int{-1, 1, 0, -2}},
+			{f: 0.259, c: [4]int{2, 1, 0, 0}},
+			{f: -0.212, c: [4]int{2, -1, 0, 2}},
+			{f: -0.151, c: [4]int{2, -1, 0, 0}},
+		},
+		{
+			{f: -526.069, c: [4]int{0, 0, 1, -2}},
+			{f: -3.352, c: [4]int{0, 0, 1, -4}},
+			{f: 44.297, c: [4]int{1, 0, 1, -2}},
+			{f: -6, c: [4]int{1, 0, 1, -4}},
+			{f: 20.599, c: [4]int{-1, 0, 1, 0}},
+			{f: -30.598, c: [4]int{-1, 0, 1, -2}},
+			{f: -24.649, c: [4]int{-2, 0, 1, 0}},
+			{f: -2, c: [4]int{-2, 0, 1, -2}},
+			{f: -22.571, c: [4]int{0, 1, 1, -2}},
+			{f: 10.985, c: [4]int{0, -1, 1, -2}},
+		},
+		{
+			{f: 0.2607, c: [4]int{0, 0, 0, 4}},
+			{f: 28.2333, c: [4]int{0, 0, 0, 2}},
+			{f: 0.0433, c: [4]int{1, 0, 0, 4}},
+			{f: 3.0861, c: [4]int{1, 0, 0, 2}},
+			{f: 186.5398, c: [4]int{1, 0, 0, 0}},
+			{f: 34.3117, c: [4]int{1, 0, 0, -2}},
+			{f: 0.6008, c: [4]int{1, 0, 0, -4}},
+			{f: -0.3, c: [4]int{0, 1, 0, 2}},
+			{f: -0.3997, c: [4]int{0, 1, 0, 0}},
+			{f: 1.9178, c: [4]int{0, 1, 0, -2}},
+			{f: 0.0339, c: [4]int{0, 1, 0, -4}},
+			{f: -0.9781, c: [4]int{0, 0, 0, 1}},
+			{f: 0.2833, c: [4]int{2, 0, 0, 2}},
+			{f: 10.1657, c: [4]int{2, 0, 0, 0}},
+			{f: -0.3039, c: [4]int{2, 0, 0, -2}},
+			{f: 0.3722, c: [4]int{2, 0, 0, -4}},
+			{f: 0.0109, c: [4]int{2, 0, 0, -6}},
+			{f: -0.0484, c: [4]int{1, 1, 0, 2}},
+			{f: -0.949, c: [4]int{1, 1, 0, 0}},
+			{f: 1.4437, c: [4]int{1, 1, 0, -2}},
+			{f: 0.0673, c: [4]int{1, 1, 0, -4}},
+			{f: 0.2302, c: [4]int{1, -1, 0, 2}},
+			{f: 1.1528, c: [4]int{1, -1, 0, 0}},
+			{f: -0.2257, c: [4]int{1, -1, 0, -2}},
+			{f: -0.0102, c: [4]int{1, -1, 0, -4}},
+			{f: 0.0918, c: [4]int{0, 2, 0, -2}},
+			{f: -0.0124, c: [4]int{0, 0, 2, 0}},
+			{f: -0.1052, c: [4]int{0, 0, 2, -2}},
+			{f: -0.1093, c: [4]int{1, 0, 0, 1}},
+			{f: 0.0118, c: [4]int{1, 0, 0, -1}},
+			{f: -0.0386, c: [4]int{1, 0, 0, -3}},
+			{f: 0.1494, c: [4]int{0, 1, 0, 1}},
+			{f: 0.0243, c: [4]int{3, 0, 0, 2}},
+			{f: 0.6215, c: [4]int{3, 0, 0, 0}},
+			{f: -0.1187, c: [4]int{3, 0, 0, -2}},
+			{f: -0.1038, c: [4]int{2, 1, 0, 0}},
+			{f: -0.0192, c: [4]int{2, 1, 0, -2}},
+			{f: 0.0324, c: [4]int{2, 1, 0, -4}},
+			{f: 0.0213, c: [4]int{2, -1, 0, 2}},
+			{f: 0.1268, c: [4]int{2, -1, 0, 0}},
+			{f: -0.0106, c: [4]int{1, 2, 0, 0}},
+			{f: 0.0484, c: [4]int{1, 2, 0, -2}},
+			{f: 0.0112, c: [4]int{1, -2, 0, 2}},
+			{f: 0.0196, c: [4]int{1, -2, 0, 0}},
+			{f: -0.0212, c: [4]int{1, -2, 0, -2}},
+			{f: -0.0833, c: [4]int{1, 0, 2, -2}},
+			{f: -0.0481, c: [4]int{1, 0, -2, 2}},
+			{f: -0.7136, c: [4]int{1, 0, -2, 0}},
+			{f: -0.0112, c: [4]int{1, 0, -2, -2}},
+			{f: -0.01, c: [4]int{2, 0, 0, 1}},
+			{f: 0.0155, c: [4]int{2, 0, 0, -1}},
+			{f: 0.0164, c: [4]int{1, 1, 0, 1}},
+			{f: 0.0401, c: [4]int{4, 0, 0, 0}},
+			{f: -0.013, c: [4]int{4, 0, 0, -2}},
+			{f: 0.0115, c: [4]int{3, -1, 0, 0}},
+			{f: -0.0141, c: [4]int{2, 0, -2, -2}},
+		},
+	}
+	sunf = [...][]float64{
+		{
+			-0.265, 0,
+			3.76, 0,
+			0.2, 0,
+		},
+		{
+			-0.021, 0,
+			5.18, 0,
+			1.882, 3.8991,
+			-0.03, 0,
+		},
+		{
+			0.075, 5.1766,
+			4.838, 5.2203,
+			0.074, 3.6285,
+			0.116, 2.5988,
+			5.526, 2.5885,
+			2.497, 5.5143,
+			0.044, 5.435,
+			0.666, 3.1016,
+			1.559, 6.0258,
+			1.024, 5.5527,
+			0.21, 3.5989,
+			0.144, 3.4104,
+			0.152, 6.0004,
+			0.084, 4.112,
+			0.037, 3.8711,
+			0.123, 3.4086,
+			0.154, 6.2762,
+			0.038, 4.6094,
+			0.02, 5.1313,
+			0.042, 4.5239,
+			0.032, 0.8517,
+			0.273, 3.7996,
+			0.048, 4.5431,
+			0.041, 6.0388,
+			2.043, 6.002,
+			1.77, 3.4977,
+			0.028, 2.5831,
+			0.129, 5.1348,
+			0.425, 5.9146,
+			0.034, 1.2391,
+			0.5, 1.8357,
+			0.585, 5.8304,
+			0.085, 0.9529,
+			0.204, 1.7593,
+			0.02, 3.2463,
+			0.154, 3.9689,
+			0.101, 1.6808,
+			0.049, 3.0805,
+			0.106, 3.8868,
+			0.052, 6.0895,
+			0.021, 3.7559,
+			0.028, 5.2011,
+			0.062, 6.0388,
+			0.044, 1.8483,
+			0.045, 3.9759,
+			0.021, 5.3931,
+			0.026, 1.9722,
+			0.163, 3.4662,
+			7.208, 3.1334,
+			2.6, 4.594,
+			0.073, 4.8223,
+			0.069, 1.4102,
+			2.731, 1.521,
+			1.61, 1.911,
+			0.073, 4.4087,
+			0.164, 2.9758,
+			0.556, 1.4425,
+			0.21, 1.7261,
+			0.044, 2.9356,
+			0.08, 1.3561,
+			0.419, 1.7555,
+			0.32, 4.703,
+			0.108, 5.0719,
+			0.112, 5.1243,
+			0.021, 5.044,
+		},
+		{
+			6.454, 0,
+			0.177, 0,
+			-0.424, 0,
+			0.039, 0,
+			-0.064, 0,
+			0.172, 0,
+		},
+		{
+			-0.092, 1.6354,
+			-0.067, 2.1468,
+			-0.21, 2.6494,
+			-0.166, 4.6338,
+		},
+		{
+			0.576, 0,
+			-0.047, 0,
+			0.021, 0,
+		},
+		{
+			2.359e-6, 3.6607,
+			6.842e-6, 1.018,
+			0.869e-6, 3.9567,
+			1.045e-6, 1.5332,
+			1.497e-6, 4.4691,
+			0.376e-6, 2.0295,
+			2.057e-6, 4.0941,
+			0.215e-6, 4.3459,
+			0.478e-6, 0.2648,
+			0.208e-6, 1.9548,
+			7.067e-6, 1.563,
+			0.244e-6, 5.9097,
+			4.026e-6, 6.2526,
+			1.459e-6, 0.3409,
+			0.281e-6, 1.4172,
+			0.803e-6, 6.1533,
+			0.429e-6, 0.185,
+		},
+		{
+			13.36e-6, 0,
+			-1.33e-6, 0,
+			0.37e-6, 0,
+			0.36e-6, 0,
+		},
+	}
+	sunc = [...][]int{
+		{
+			4, -7, 3, 0,
+			-8, 4, 0, 3,
+			15, -8, 0, 0,
+		},
+		{
+			4, -7, 3, 0, 0,
+			-8, 4, 0, 3, 0,
+			0, 13, -8, 0, 1,
+			15, -8, 0, 0, 0,
+		},
+		{
+			0, 0, 1, 0, 0,
+			0, -1, 1, 0, 0,
+			0, -2, 1, 0, 0,
+			0, -1, 2, 0, 0,
+			0, -2, 2, 0, 0,
+			0, -3, 2, 0, 0,
+			0, -4, 2, 0, 0,
+			0, -3, 3, 0, 0,
+			0, -4, 3, 0, 0,
+			0, -5, 3, 0, 0,
+			0, -4, 4, 0, 0,
+			0, -5, 4, 0, 0,
+			0, -6, 4, 0, 0,
+			0, -5, 5, 0, 0,
+			0, -6, 5, 0, 0,
+			0, -7, 5, 0, 0,
+			0, -8, 5, 0, 0,
+			0, -6, 6, 0, 0,
+			0, -7, 7, 0, 0,
+			0, -12, 8, 0, 0,
+			0, -14, 8, 0, 0,
+			-1, 1, 0, 0, 0,
+			-1, 0, 0, 0, 0,
+			-2, 3, 0, 0, 0,
+			-2, 2, 0, 0, 0,
+			-2, 1, 0, 0, 0,
+			-2, 0, 0, 0, 0,
+			-3, 3, 0, 0, 0,
+			-3, 2, 0, 0, 0,
+			-4, 4, 0, 0, 0,
+			-4, 3, 0, 0, 0,
+			-4, 2, 0, 0, 0,
+			-5, 4, 0, 0, 0,
+			-5, 3, 0, 0, 0,
+			-6, 5, 0, 0, 0,
+			-6, 4, 0, 0, 0,
+			-6, 3, 0, 0, 0,
+			-7, 5, 0, 0, 0,
+			-7, 4, 0, 0, 0,
+			-8, 5, 0, 0, 0,
+			-8, 4, 0, 0, 0,
+			-9, 6, 0, 0, 0,
+			-9, 5, 0, 0, 0,
+			-11, 6, 0, 0, 0,
+			-13, 7, 0, 0, 0,
+			-15, 9, 0, 0, 0,
+			-17, 9, 0, 0, 0,
+			0, 2, 0, -1, 0,
+			0, 1, 0, -1, 0,
+			0, 0, 0, -1, 0,
+			0, -1, 0, -1, 0,
+			0, 3, 0, -2, 0,
+			0, 2, 0, -2, 0,
+			0, 1, 0, -2, 0,
+			0, 0, 0, -2, 0,
+			0, 3, 0, -3, 0,
+			0, 2, 0, -3, 0,
+			0, 1, 0, -3, 0,
+			0, 3, 0, -4, 0,
+			0, 2, 0, -4, 0,
+			0, 1, 0, 0, -1,
+			0, 0, 0, 0, -1,
+			0, 2, 0, 0, -2,
+			0, 1, 0, 0, -2,
+			0, 2, 0, 0, -3,
+		},
+		{
+			1, 0, 0,
+			1, 1, 0,
+			1, -1, 0,
+			3, -1, 0,
+			1, 0, 1,
+			1, 0, -1,
+		},
+		{
+			-2, 1, 0,
+			-3, 2, 0,
+			-4, 2, 0,
+			1, 0, -2,
+		},
+		{
+			1, 0, 0,
+			1, -1, 0,
+			-1, 0, 2,
+		},
+		{
+			0, -1, 1, 0, 0,
+			0, -2, 2, 0, 0,
+			0, -3, 2, 0, 0,
+			0, -3, 3, 0, 0,
+			0, -4, 3, 0, 0,
+			0, -4, 4, 0, 0,
+			-2, 2, 0, 0, 0,
+			-3, 2, 0, 0, 0,
+			-4, 3, 0, 0, 0,
+			0, 2, 0, -1, 0,
+			0, 1, 0, -1, 0,
+			0, 0, 0, -1, 0,
+			0, 2, 0, -2, 0,
+			0, 1, 0, -2, 0,
+			0, 3, 0, -3, 0,
+			0, 2, 0, -3, 0,
+			0, 1, 0, 0, -1,
+		},
+		{
+			1, 0, 0,
+			1, -1, 0,
+			1, 1, 0,
+			1, 0, -1,
+		},
+	}
+	mercf = [...][]float64{
+		{
+			0.013, 0.6807,
+			0.048, 0.6283,
+			0.185, 0.6231,
+			0.711, 0.6191,
+			0.285, 0.5784,
+			0.075, 0.5411,
+			0.019, 0.5585,
+			0.01, 2.8449,
+			0.039, 2.8117,
+			0.147, 2.8135,
+			0.552, 2.8126,
+			2.1, 2.8126,
+			3.724, 2.8046,
+			0.729, 2.7883,
+			0.186, 2.789,
+			0.049, 2.7943,
+			0.013, 2.7402,
+			0.033, 1.8361,
+			0.118, 1.8396,
+			0.431, 1.8391,
+			1.329, 1.8288,
+			0.539, 4.8686,
+			0.111, 4.8904,
+			0.027, 4.8956,
+			0.012, 3.9794,
+			0.056, 3.9636,
+			0.294, 3.991,
+			0.484, 3.9514,
+			0.07, 3.927,
+			0.018, 3.927,
+			0.013, 6.1261,
+			0.05, 6.1052,
+			0.185, 6.1069,
+			0.685, 6.1011,
+			2.81, 6.1062,
+			7.356, 6.0699,
+			1.471, 6.0685,
+			0.375, 6.0687,
+			0.098, 6.072,
+			0.026, 6.0476,
+			0.062, 5.154,
+			0.122, 5.1191,
+			0.011, 0.9076,
+			0.074, 1.0123,
+			0.106, 0.9372,
+			0.017, 0.9425,
+			0.02, 0.0506,
+			0.052, 0.0384,
+			0.052, 3.0281,
+			0.012, 3.0543,
+			0.011, 2.1642,
+			0.016, 2.234,
+			0.04, 4.3912,
+			0.08, 4.4262,
+			0.016, 4.4506,
+		},
+		{
+			0.014, 1.0996,
+			0.056, 1.1153,
+			0.219, 1.116,
+			0.083, 1.0734,
+			0.024, 0.9442,
+			0.018, 3.8432,
+			0.07, 3.8293,
+			0.256, 3.823,
+			0.443, 3.8132,
+			0.08, 3.7647,
+			0.02, 3.7734,
+			0.019, 0,
+			0.133, 0.1134,
+			0.129, 6.2588,
+			0.026, 6.2413,
+			0.026, 2.6599,
+			0.087, 2.6232,
+			0.374, 2.6496,
+			0.808, 2.547,
+			0.129, 2.5587,
+			0.019, 2.5534,
+			0.012, 2.1642,
+		},
+		{
+			0.014, 3.1416,
+			0.047, 3.1625,
+			0.179, 3.1695,
+			0.697, 3.1603,
+			0.574, 4.1315,
+			0.181, 4.2537,
+			0.047, 4.2481,
+			0.013, 4.2062,
+			0.018, 0.665,
+			0.069, 0.6405,
+			0.253, 0.6449,
+			0.938, 0.6454,
+			3.275, 0.6458,
+			0.499, 0.5569,
+			0.119, 0.5271,
+			0.032, 0.5184,
+			0.03, 0.4939,
+			0.106, 0.4171,
+			0.353, 0.451,
+			0.056, 0.384,
+			0.013, 0.3142,
+			0.028, 0.2531,
+		},
+		{
+			0.034, 0.9512,
+			0.06, 4.7962,
+			0.028, 4.7124,
+			0.028, 4.1836,
+			0.102, 4.1871,
+			0.38, 4.1864,
+			0.059, 4.1818,
+			0.015, 4.2185,
+			0.012, 4.1713,
+			0.05, 4.187,
+		},
+		{
+			0.218e-6, 5.3369,
+			0.491e-6, 5.3281,
+			0.172e-6, 2.1642,
+			0.091e-6, 2.1084,
+			0.204e-6, 1.246,
+			0.712e-6, 1.2413,
+			2.370e-6, 1.2425,
+			0.899e-6, 1.2303,
+			0.763e-6, 4.3633,
+			0.236e-6, 4.359,
+			0.163e-6, 0.2705,
+			0.541e-6, 0.271,
+			1.157e-6, 0.259,
+			0.099e-6, 0.1798,
+			0.360e-6, 2.4237,
+			0.234e-6, 2.374,
+			0.253e-6, 4.5365,
+			0.849e-6, 4.5293,
+			2.954e-6, 4.5364,
+			0.282e-6, 4.4581,
+			1.550e-6, 1.357,
+			0.472e-6, 1.3561,
+			0.135e-6, 1.3579,
+			0.081e-6, 3.5936,
+			0.087e-6, 3.55,
+			0.087e-6, 5.7334,
+		},
+		{
+			0.181e-6, 5.8275,
+			0.095e-6, 2.2427,
+			0.319e-6, 2.2534,
+			0.256e-6, 2.2403,
+			0.157e-6, 4.8292,
+			0.106e-6, 1.0332,
+			0.397e-6, 1.0756,
+			0.143e-6, 4.098,
+		},
+		{
+			0.222e-6, 1.6024,
+			0.708e-6, 1.5949,
+			0.191e-6, 5.7914,
+			0.1e-6, 5.3564,
+			0.347e-6, 5.3548,
+			1.185e-6, 5.3576,
+			3.268e-6, 5.3579,
+			0.371e-6, 2.2148,
+			0.16e-6, 2.1241,
+			0.134e-6, 5.126,
+			0.347e-6, 5.162,
+		},
+	}
+	mercc = [...][]int{
+		{
+			4, 1,
+			3, 1,
+			2, 1,
+			1, 1,
+			0, 1,
+			-1, 1,
+			-2, 1,
+			6, 2,
+			5, 2,
+			4, 2,
+			3, 2,
+			2, 2,
+			1, 2,
+			0, 2,
+			-1, 2,
+			-2, 2,
+			-3, 2,
+			5, 3,
+			4, 3,
+			3, 3,
+			2, 3,
+			1, 3,
+			0, 3,
+			-1, 3,
+			5, 4,
+			4, 4,
+			3, 4,
+			2, 4,
+			1, 4,
+			0, 4,
+			7, 5,
+			6, 5,
+			5, 5,
+			4, 5,
+			3, 5,
+			2, 5,
+			1, 5,
+			0, 5,
+			-1, 5,
+			-2, 5,
+			4, 6,
+			3, 6,
+			5, 7,
+			4, 7,
+			3, 7,
+			2, 7,
+			5, 8,
+			4, 8,
+			3, 8,
+			2, 8,
+			5, 9,
+			4, 9,
+			5, 10,
+			4, 10,
+			3, 10,
+		},
+		{
+			3, 1,
+			2, 1,
+			1, 1,
+			0, 1,
+			-1, 1,
+			4, 2,
+			3, 2,
+			2, 2,
+			1, 2,
+			0, 2,
+			-1, 2,
+			3, 3,
+			2, 3,
+			1, 3,
+			0, 3,
+			4, 4,
+			3, 4,
+			2, 4,
+			1, 4,
+			0, 4,
+			-1, 4,
+			2, 5,
+		},
+		{
+			4, 1,
+			3, 1,
+			2, 1,
+			1, 1,
+			0, 1,
+			-1, 1,
+			-2, 1,
+			-3, 1,
+			5, 2,
+			4, 2,
+			3, 2,
+			2, 2,
+			1, 2,
+			0, 2,
+			-1, 2,
+			-2, 2,
+			3, 3,
+			2, 3,
+			1, 3,
+			0, 3,
+			-1, 3,
+			1, 4,
+		},
+		{
+			1, 1,
+			0, 1,
+			-1, 1,
+			3, 2,
+			2, 2,
+			1, 2,
+			0, 2,
+			-1, 2,
+			2, 3,
+			1, 3,
+		},
+		{
+			2, 1,
+			1, 1,
+			0, 1,
+			-1, 1,
+			4, 2,
+			3, 2,
+			2, 2,
+			1, 2,
+			0, 2,
+			-1, 2,
+			4, 3,
+			3, 3,
+			2, 3,
+			0, 3,
+			3, 4,
+			2, 4,
+			5, 5,
+			4, 5,
+			3, 5,
+			2, 5,
+			1, 5,
+			0, 5,
+			-1, 5,
+			4, 6,
+			3, 6,
+			4, 7,
+		},
+		{
+			1, 1,
+			3, 2,
+			2, 2,
+			1, 2,
+			2, 3,
+			3, 4,
+			2, 4,
+			0, 4,
+		},
+		{
+			2, 1,
+			1, 1,
+			-1, 1,
+			4, 2,
+			3, 2,
+			2, 2,
+			1, 2,
+			0, 2,
+			-1, 2,
+			2, 3,
+			1, 3,
+		},
+	}
+	venf = [...][]float64{
+		{
+			4.889, 2.0788,
+			11.261, 2.587,
+			7.128, 6.2384,
+			3.446, 2.3721,
+			1.034, 0.4632,
+			1.575, 3.3847,
+			1.439, 2.4099,
+			1.208, 4.1464,
+			2.966, 3.6318,
+			1.563, 4.6829,
+		},
+		{
+			0.122, 4.2726,
+			0.3, 0.0218,
+			0.159, 1.3491,
+		},
+		{
+			2.246e-6, 0.508,
+			9.772e-6, 1.0159,
+			8.271e-6, 4.6674,
+			0.737e-6, 0.8267,
+			1.426e-6, 5.1747,
+			0.51e-6, 5.7009,
+			1.572e-6, 1.8188,
+			0.717e-6, 2.2969,
+			2.991e-6, 2.0611,
+			1.335e-6, 0.9628,
+		},
+	}
+	venc = [...][]int{
+		{
+			1, -1, 0, 0,
+			2, -2, 0, 0,
+			3, -3, 0, 0,
+			2, -3, 0, 0,
+			4, -4, 0, 0,
+			4, -5, 0, 0,
+			3, -5, 0, 0,
+			1, 0, -3, 0,
+			1, 0, 0, -1,
+			0, 0, 0, -1,
+		},
+		{
+			0, -1, 0,
+			4, -5, 0,
+			1, 0, -2,
+		},
+		{
+			1, -1, 0, 0,
+			2, -2, 0, 0,
+			3, -3, 0, 0,
+			2, -3, 0, 0,
+			4, -4, 0, 0,
+			5, -5, 0, 0,
+			4, -5, 0, 0,
+			2, 0, -3, 0,
+			1, 0, 0, -1,
+			2, 0, 0, -2,
+		},
+	}
+	nutf = [...][]float64{
+		{
+			0.2088, 0,
+			-1.273, 0,
+			0.1258, 0,
+			-0.0496, 0,
+			0.0214, 0,
+			0.0124, 0,
+		},
+		{
+			9.2109, 0,
+			-0.0904, 0,
+			0.5519, 0,
+			0.0215, 0,
+			-0.0093, 0,
+			-0.0066, 0,
+		},
+		{
+			-0.2037, 0,
+			0.0675, 0,
+			-0.0342, 0,
+			-0.0261, 0,
+			-0.0149, 0,
+			0.0114, 0,
+			0.006, 0,
+			0.0058, 0,
+			-0.0057, 0,
+			-0.0052, 0,
+		},
+		{
+			0.0884, 0,
+			0.0183, 0,
+			0.0113, 0,
+			-0.005, 0,
+		},
+	}
+	nutc = [...][]int{
+		{
+			2, 0, 0, 0,
+			2, 2, -2, 0,
+			0, 0, 0, 1,
+			2, 2, -2, 1,
+			2, 2, -2, -1,
+			1, 2, -2, 0,
+		},
+		{
+			1, 0, 0, 0,
+			2, 0, 0, 0,
+			2, 2, -2, 0,
+			2, 2, -2, 1,
+			2, 2, -2, -1,
+			1, 2, -2, 0,
+		},
+		{
+			2, 2, 0, 0,
+			0, 0, 1, 0,
+			1, 2, 0, 0,
+			2, 2, 1, 0,
+			0, 0, 1, -2,
+			2, 2, -1, 0,
+			0, 0, 0, 2,
+			1, 0, 1, 0,
+			1, 0, -1, 0,
+			2, 2, -1, 2,
+		},
+		{
+			2, 2, 0,
+			1, 2, 0,
+			2, 2, 1,
+			2, 2, -1,
+		},
+	}
+	elemUran = []float64{
+		36525,       // eday of epoc
+		19.19126393, // semi major axis (au)
+		0.04716771,  // eccentricity
+		0.76986,     // inclination (deg)
+		74.22988,    // longitude of the ascending node (deg)
+		170.96424,   // longitude of perihelion (deg)
+		313.23218,   // mean longitude (deg)
+		0.00152025,  // (au/julian century)
+		-0.0001915,  // (e/julian century)
+		-2.09,       // (arcsec/julian century)
+		-1681.4,     // (arcsec/julian century)
+		1312.56,     // (arcsec/julian century)
+		1542547.79,  // (arcsec/julian century)
+	}
+	elemNept = []float64{
+		36525,       // eday of epoc
+		30.06896348, // semi major axis (au)
+		0.00858587,  // eccentricity
+		1.76917,     // inclination (deg)
+		131.72169,   // longitude of the ascending node (deg)
+		44.97135,    // longitude of perihelion (deg)
+		304.88003,   // mean longitude (deg)
+		-0.00125196, // (au/julian century)
+		0.0000251,   // (e/julian century)
+		-3.64,       // (arcsec/julian century)
+		-151.25,     // (arcsec/julian century)
+		-844.43,     // (arcsec/julian century)
+		786449.21,   // (arcsec/julian century)
+	}
+	elemPlut = []float64{
+		36525,       // eday of epoc
+		39.48168677, // semi major axis (au)
+		0.24880766,  // eccentricity
+		17.14175,    // inclination (deg)
+		110.30347,   // longitude of the ascending node (deg)
+		224.06676,   // longitude of perihelion (deg)
+		238.92881,   // mean longitude (deg)
+		-0.00076912, // (au/julian century)
+		0.00006465,  // (e/julian century)
+		11.07,       // (arcsec/julian century)
+		-37.33,      // (arcsec/julian century)
+		-132.25,     // (arcsec/julian century)
+		522747.9,    // (arcsec/julian century)
+	}
+	// The last interesting comet, reported when no others are given.
+	comet153P = Comet{
+		Designation: "153P",
+		Name:        "153P/Ikeya–Zhang",
+		Perihelion:  time.Date(2002, 3, 18, 23, 28, 53, 760000000, time.UTC),
+		Q:           0.5070601,
+		E:           0.990111,
+		Peri:        34.6666,
+		Node:        93.1206,
+		Incl:        28.12106,
+		H:           5.47,
+		K:           2.44,
+	}
+	solstr = []string{"Fall equinox", "Winter solstice", "Spring equinox", "Summer solstice"}
+	// Ecliptic longitude (J2000, deg) at which the ecliptic enters each
+	// constellation it crosses.
+	zodiac = []struct {
+		lon  float64
+		name string
+	}{
+		{28.69, "Aries"},
+		{53.42, "Taurus"},
+		{90.43, "Gemini"},
+		{118.26, "Cancer"},
+		{138.18, "Leo"},
+		{174.15, "Virgo"},
+		{217.81, "Libra"},
+		{241.14, "Scorpius"},
+		{247.70, "Ophiuchus"},
+		{266.30, "Sagittarius"},
+		{299.71, "Capricornus"},
+		{327.88, "Aquarius"},
+		{351.57, "Pisces"},
+	}
+	// The major meteor showers of the working list of the International
+	// Meteor Organization, in order of their peaks: the start, end, and peak
+	// of activity in solar longitude and the radiant, both J2000, with the
+	// zenithal hourly rate, velocity, and population index.
+	meteorShowers = []Shower{
+		{"QUA", "Quadrantids", 276.0, 291.7, 283.15, 80, 230, 49, 41, 2.1},
+		{"LYR", "Lyrids", 24.2, 39.9, 32.32, 18, 271, 34, 49, 2.1},
+		{"ETA", "Eta Aquariids", 29.0, 66.8, 45.5, 50, 338, -1, 66, 2.4},
+		{"PAU", "Piscis Austrinids", 112.5, 137.4, 125, 5, 341, -30, 35, 3.2},
+		{"SDA", "Southern Delta Aquariids", 109.7, 149.9, 127, 25, 340, -16, 41, 2.5},
+		{"CAP", "Alpha Capricornids", 101.1, 142.2, 127, 5, 306, -8, 23, 2.5},
+		{"PER", "Perseids", 114.5, 150.8, 140.0, 100, 48, 58, 59, 2.2},
+		{"KCG", "Kappa Cygnids", 130.7, 151.8, 145, 3, 286, 59, 25, 3.0},
+		{"AUR", "Aurigids", 154.7, 162.4, 158.6, 6, 91, 39, 66, 2.5},
+		{"DRA", "Draconids", 192.5, 196.5, 195.4, 10, 262, 54, 20, 2.6},
+		{"STA", "Southern Taurids", 167.2, 237.6, 197, 5, 32, 9, 27, 2.3},
+		{"ORI", "Orionids", 188.6, 224.5, 208, 20, 95, 16, 66, 2.5},
+		{"NTA", "Northern Taurids", 206.5, 258.0, 230, 5, 58, 22, 29, 2.3},
+		{"LEO", "Leonids", 223.5, 247.9, 235.27, 15, 152, 22, 70, 2.5},
+		{"AMO", "Alpha Monocerotids", 232.5, 242.9, 239.32, 5, 117, 1, 65, 2.4},
+		{"PHE", "Phoenicids", 245.9, 257.0, 249.9, 3, 18, -53, 18, 2.8},
+		{"GEM", "Geminids", 251.9, 268.2, 262.2, 150, 112, 33, 35, 2.6},
+		{"URS", "Ursids", 265.1, 274.2, 270.7, 10, 217, 76, 33, 3.0},
+	}
+	// Named stars brighter than about fourth magnitude, with the fainter named
+	// stars near the ecliptic that the moon occults, in order of right
+	// ascension. Positions are J2000 and proper motions are in mas/yr.
+	brightStars = []Star{
+		{"Alpheratz", "α And", 2.09692, 29.09044, 135.68, -162.95, 2.06},
+		{"Caph", "β Cas", 2.29454, 59.14978, 523.50, -180.18, 2.27},
+		{"Algenib", "γ Peg", 3.30896, 15.18358, 4.70, -8.24, 2.83},
+		{"Ankaa", "α Phe", 6.57104, -42.30597, 233.05, -356.30, 2.40},
+		{"Schedar", "α Cas", 10.12683, 56.53733, 50.88, -32.13, 2.24},
+		{"Diphda", "β Cet", 10.89738, -17.98661, 232.79, 32.71, 2.04},
+		{"Navi", "γ Cas", 14.17721, 60.71675, 25.65, -3.82, 2.15},
+		{"Mirach", "β And", 17.43300, 35.62056, 175.59, -112.23, 2.05},
+		{"Ruchbah", "δ Cas", 21.45396, 60.23528, 297.24, -49.49, 2.68},
+		{"Achernar", "α Eri", 24.42854, -57.23675, 88.02, -40.08, 0.46},
+		{"Segin", "ε Cas", 28.59887, 63.67011, 32.04, -18.34, 3.38},
+		{"Sheratan", "β Ari", 28.66004, 20.80803, 96.32, -108.80, 2.64},
+		{"Alrescha", "α Psc", 30.51175, 2.76375, 31.62, -0.10, 3.82},
+		{"Almach", "γ And", 30.97479, 42.32972, 43.08, -50.85, 2.10},
+		{"Hamal", "α Ari", 31.79338, 23.46242, 190.73, -145.77, 2.00},
+		{"Mira", "ο Cet", 34.83662, -2.97764, 10.33, -239.48, 3.04},
+		{"Polaris", "α UMi", 37.95454, 89.26411, 44.22, -11.74, 1.98},
+		{"Acamar", "θ1 Eri", 44.56533, -40.30472, -52.89, 21.98, 2.88},
+		{"Menkar", "α Cet", 45.56987, 4.08975, -11.81, -78.76, 2.54},
+		{"Algol", "β Per", 47.04221, 40.95564, 2.39, -1.44, 2.12},
+		{"Botein", "δ Ari", 47.90738, 19.72667, 153.70, -8.60, 4.35},
+		{"Mirfak", "α Per", 51.08071, 49.86117, 24.11, -26.01, 1.79},
+		{"Celaeno", "16 Tau", 56.20092, 24.28947, 20.73, -44.98, 5.45},
+		{"Electra", "17 Tau", 56.21892, 24.11333, 21.55, -44.92, 3.70},
+		{"Taygeta", "19 Tau", 56.30208, 24.46728, 19.35, -41.63, 4.30},
+		{"Maia", "20 Tau", 56.45671, 24.36775, 21.09, -45.03, 3.87},
+		{"Merope", "23 Tau", 56.58154, 23.94836, 21.17, -43.78, 4.18},
+		{"Alcyone", "η Tau", 56.87117, 24.10514, 19.34, -43.67, 2.87},
+		{"Atlas", "27 Tau", 57.29058, 24.05342, 17.77, -44.70, 3.63},
+		{"Pleione", "28 Tau", 57.29675, 24.13672, 18.71, -46.74, 5.05},
+		{"Zaurak", "γ Eri", 59.50738, -13.50853, 61.57, -113.11, 2.95},
+		{"Ain", "ε Tau", 67.15413, 19.18044, 107.23, -36.77, 3.53},
+		{"Aldebaran", "α Tau", 68.98017, 16.50931, 62.78, -189.36, 0.85},
+		{"Hassaleh", "ι Aur", 74.24842, 33.16608, 3.63, -18.54, 2.69},
+		{"Almaaz", "ε Aur", 75.49221, 43.82331, 0.18, -2.31, 2.99},
+		{"Cursa", "β Eri", 76.96246, -5.08644, -83.39, -75.44, 2.79},
+		{"Rigel", "β Ori", 78.63446, -8.20164, 1.31, 0.50, 0.13},
+		{"Capella", "α Aur", 79.17233, 45.99800, 75.52, -427.13, 0.08},
+		{"Bellatrix", "γ Ori", 81.28275, 6.34969, -8.75, -13.28, 1.64},
+		{"Elnath", "β Tau", 81.57296, 28.60744, 23.28, -174.22, 1.65},
+		{"Nihal", "β Lep", 82.06133, -20.75944, -5.03, -85.92, 2.84},
+		{"Mintaka", "δ Ori", 83.00167, -0.29908, 0.64, -0.69, 2.23},
+		{"Arneb", "α Lep", 83.18258, -17.82228, 3.56, 1.18, 2.58},
+		{"Alnilam", "ε Ori", 84.05337, -1.20192, 1.49, -1.06, 1.69},
+		{"Phact", "α Col", 84.91225, -34.07411, 1.41, -24.87, 2.65},
+		{"Alnitak", "ζ Ori", 85.18971, -1.94258, 3.19, 2.03, 1.77},
+		{"Saiph", "κ Ori", 86.93913, -9.66961, 1.55, -1.20, 2.06},
+		{"Betelgeuse", "α Ori", 88.79296, 7.40706, 27.33, 10.86, 0.50},
+		{"Menkalinan", "β Aur", 89.88217, 44.94744, -56.44, -0.95, 1.90},
+		{"Propus", "η Gem", 93.71942, 22.50681, -62.20, -11.60, 3.28},
+		{"Mirzam", "β CMa", 95.67496, -17.95592, -3.45, -0.47, 1.98},
+		{"Tejat", "μ Gem", 95.74012, 22.51358, 56.84, -110.39, 2.87},
+		{"Canopus", "α Car", 95.98796, -52.69567, 19.93, 23.24, -0.74},
+		{"Alhena", "γ Gem", 99.42796, 16.39928, -2.04, -66.92, 1.93},
+		{"Mebsuta", "ε Gem", 100.98304, 25.13111, -6.06, -13.08, 2.98},
+		{"Sirius", "α CMa", 101.28717, -16.71611, -546.01, -1223.07, -1.46},
+		{"Adhara", "ε CMa", 104.65646, -28.97208, 3.24, 1.33, 1.50},
+		{"Mekbuda", "ζ Gem", 106.02721, 20.57031, -6.46, -0.36, 3.79},
+		{"Wezen", "δ CMa", 107.09783, -26.39319, -3.12, 3.31, 1.84},
+		{"Wasat", "δ Gem", 110.03075, 21.98233, -18.72, -7.10, 3.53},
+		{"Aludra", "η CMa", 111.02375, -29.30311, -3.76, 6.66, 2.45},
+		{"Gomeisa", "β CMi", 111.78767, 8.28931, -51.05, -37.73, 2.89},
+		{"Castor", "α Gem", 113.64942, 31.88828, -191.45, -145.19, 1.58},
+		{"Procyon", "α CMi", 114.82550, 5.22500, -714.59, -1036.80, 0.34},
+		{"Pollux", "β Gem", 116.32896, 28.02619, -626.55, -45.80, 1.14},
+		{"Naos", "ζ Pup", 120.89604, -40.00314, -30.82, 16.77, 2.25},
+		{"Regor", "γ2 Vel", 122.38313, -47.33658, -5.93, 9.90, 1.83},
+		{"Avior", "ε Car", 125.62850, -59.50947, -25.52, 22.72, 1.86},
+		{"Asellus Borealis", "γ Cnc", 130.82146, 21.46850, -106.08, -39.63, 4.66},
+		{"Asellus Australis", "δ Cnc", 131.17125, 18.15431, -17.67, -228.46, 3.94},
+		{"Alsephina", "δ Vel", 131.17596, -54.70883, 28.78, -103.08, 1.96},
+		{"Acubens", "α Cnc", 134.62175, 11.85769, 41.45, -29.52, 4.26},
+		{"Suhail", "λ Vel", 136.99900, -43.43258, -23.21, 14.28, 2.21},
+		{"Miaplacidus", "β Car", 138.29992, -69.71719, -156.47, 108.95, 1.67},
+		{"Aspidiske", "ι Car", 139.27254, -59.27522, -19.03, 13.11, 2.21},
+		{"Alphard", "α Hya", 141.89683, -8.65861, -15.23, 34.37, 1.98},
+		{"Subra", "ο Leo", 145.28762, 9.89231, -143.08, -37.78, 3.52},
+		{"Rasalas", "μ Leo", 148.19092, 26.00694, -217.17, -55.15, 3.88},
+		{"Regulus", "α Leo", 152.09296, 11.96722, -248.73, 5.59, 1.40},
+		{"Adhafera", "ζ Leo", 154.17258, 23.41731, 19.83, -6.86, 3.43},
+		{"Algieba", "γ1 Leo", 154.99317, 19.84150, 310.77, -152.88, 2.08},
+		{"Alkes", "α Crt", 164.94358, -18.29878, -462.01, 129.09, 4.07},
+		{"Merak", "β UMa", 165.46033, 56.38242, 81.43, 33.49, 2.37},
+		{"Dubhe", "α UMa", 165.93196, 61.75103, -134.11, -34.70, 1.79},
+		{"Zosma", "δ Leo", 168.52708, 20.52372, 143.26, -130.43, 2.56},
+		{"Chertan", "θ Leo", 168.56000, 15.42958, -60.36, -79.43, 3.33},
+		{"Denebola", "β Leo", 177.26492, 14.57206, -497.68, -114.67, 2.14},
+		{"Zavijava", "β Vir", 177.67383, 1.76472, 740.23, -270.43, 3.61},
+		{"Phecda", "γ UMa", 178.45771, 53.69475, 107.68, 11.01, 2.44},
+		{"Gienah", "γ Crv", 183.95154, -17.54192, -159.58, 22.31, 2.59},
+		{"Zaniah", "η Vir", 184.97650, -0.66681, -59.99, -25.46, 3.89},
+		{"Acrux", "α1 Cru", 186.64958, -63.09908, -35.37, -14.73, 0.77},
+		{"Algorab", "δ Crv", 187.46608, -16.51544, -210.56, -138.77, 2.95},
+		{"Gacrux", "γ Cru", 187.79150, -57.11322, 28.23, -265.08, 1.63},
+		{"Kraz", "β Crv", 188.59679, -23.39675, 0.86, -56.00, 2.65},
+		{"Muhlifain", "γ Cen", 190.37933, -48.95986, -186.87, -1.20, 2.20},
+		{"Porrima", "γ Vir", 190.41517, -1.44936, -616.66, 60.66, 2.74},
+		{"Mimosa", "β Cru", 191.93029, -59.68878, -42.97, -16.18, 1.25},
+		{"Alioth", "ε UMa", 193.50729, 55.95983, 111.91, -8.24, 1.77},
+		{"Minelauva", "δ Vir", 193.90087, 3.39747, -471.44, -52.81, 3.38},
+		{"Cor Caroli", "α2 CVn", 194.00696, 38.31839, -235.08, 53.54, 2.90},
+		{"Vindemiatrix", "ε Vir", 195.54417, 10.95914, -273.80, 19.96, 2.83},
+		{"Mizar", "ζ UMa", 200.98142, 54.92536, 119.01, -25.97, 2.23},
+		{"Spica", "α Vir", 201.29825, -11.16133, -42.35, -30.67, 0.97},
+		{"Heze", "ζ Vir", 203.67329, -0.59581, -278.89, 48.56, 3.38},
+		{"Alkaid", "η UMa", 206.88517, 49.31328, -121.17, -14.91, 1.86},
+		{"Muphrid", "η Boo", 208.67117, 18.39772, -60.95, -356.29, 2.68},
+		{"Hadar", "β Cen", 210.95588, -60.37303, -33.27, -23.16, 0.61},
+		{"Thuban", "α Dra", 211.09729, 64.37586, -56.52, 17.19, 3.65},
+		{"Menkent", "θ Cen", 211.67062, -36.36994, -519.29, -517.87, 2.06},
+		{"Arcturus", "α Boo", 213.91529, 19.18242, -1093.39, -2000.06, -0.05},
+		{"Rigil Kentaurus", "α Cen", 219.90204, -60.83400, -3679.25, 473.67, -0.27},
+		{"Izar", "ε Boo", 221.24675, 27.07422, -50.95, 21.07, 2.37},
+		{"Kochab", "β UMi", 222.67638, 74.15550, -32.61, 11.42, 2.08},
+		{"Zubenelgenubi", "α2 Lib", 222.71963, -16.04178, -105.68, -68.40, 2.75},
+		{"Nekkar", "β Boo", 225.48650, 40.39056, -40.15, -28.86, 3.49},
+		{"Zubeneschamali", "β Lib", 229.25171, -9.38292, -95.10, -21.72, 2.61},
+		{"Alphecca", "α CrB", 233.67196, 26.71469, 120.27, -89.58, 2.23},
+		{"Unukalhai", "α Ser", 236.06696, 6.42564, 133.84, 44.81, 2.63},
+		{"Fang", "π Sco", 239.71296, -26.11411, -11.06, -26.18, 2.89},
+		{"Dschubba", "δ Sco", 240.08337, -22.62169, -8.44, -36.80, 2.32},
+		{"Acrab", "β1 Sco", 241.35929, -19.80544, -5.20, -24.04, 2.62},
+		{"Yed Prior", "δ Oph", 243.58642, -3.69433, -46.13, -142.91, 2.73},
+		{"Alniyat", "σ Sco", 245.29717, -25.59281, -10.60, -16.40, 2.89},
+		{"Antares", "α Sco", 247.35192, -26.43200, -12.11, -23.30, 0.96},
+		{"Kornephoros", "β Her", 247.55500, 21.48961, -98.43, -14.49, 2.78},
+		{"Paikauhale", "τ Sco", 248.97063, -28.21603, -9.89, -22.83, 2.82},
+		{"Atria", "α TrA", 252.16625, -69.02772, 17.99, -31.58, 1.92},
+		{"Larawag", "ε Sco", 252.54087, -34.29322, -611.84, -255.87, 2.29},
+		{"Sabik", "η Oph", 257.59454, 15.72492, 40.13, 99.17, 2.43},
+		{"Rasalgethi", "α1 Her", 258.66192, 14.39033, -7.32, 36.07, 3.35},
+		{"Rastaban", "β Dra", 262.60817, 52.30139, -15.59, 11.57, 2.79},
+		{"Shaula", "λ Sco", 263.40217, -37.10383, -8.53, -30.80, 1.62},
+		{"Rasalhague", "α Oph", 263.73362, 12.56003, 108.07, -221.57, 2.08},
+		{"Sargas", "θ Sco", 264.32971, -42.99783, 6.06, -0.95, 1.87},
+		{"Cebalrai", "β Oph", 265.86812, 4.56731, -40.67, 158.80, 2.77},
+		{"Eltanin", "γ Dra", 269.15154, 51.48889, -8.48, -22.79, 2.24},
+		{"Alnasl", "γ2 Sgr", 271.45204, -30.42408, -55.59, -181.53, 2.98},
+		{"Polis", "μ Sgr", 273.44087, -21.05883, 1.03, -0.20, 3.84},
+		{"Kaus Media", "δ Sgr", 275.24850, -29.82811, 32.48, -25.97, 2.72},
+		{"Kaus Australis", "ε Sgr", 276.04300, -34.38461, -39.42, -124.20, 1.85},
+		{"Kaus Borealis", "λ Sgr", 276.99267, -25.42169, -44.81, -185.90, 2.82},
+		{"Vega", "α Lyr", 279.23475, 38.78369, 200.94, 286.23, 0.03},
+		{"Sheliak", "β Lyr", 282.52000, 33.36267, 1.10, -4.46, 3.52},
+		{"Nunki", "σ Sgr", 283.81638, -26.29672, 15.14, -53.43, 2.05},
+		{"Sulafat", "γ Lyr", 284.73592, 32.68956, -2.76, 1.08, 3.24},
+		{"Ascella", "ζ Sgr", 285.65304, -29.88011, -10.00, 3.00, 2.60},
+		{"Albaldah", "π Sgr", 287.44096, -21.02361, -0.72, -36.46, 2.88},
+		{"Albireo", "β1 Cyg", 292.68033, 27.95967, -7.09, -6.15, 3.05},
+		{"Tarazed", "γ Aql", 296.56492, 10.61325, 15.72, -3.08, 2.72},
+		{"Altair", "α Aql", 297.69579, 8.86833, 536.23, 385.29, 0.77},
+		{"Alshain", "β Aql", 298.82829, 6.40675, 46.35, -481.32, 3.71},
+		{"Algedi", "α2 Cap", 304.51358, -12.54486, 62.66, 1.94, 3.57},
+		{"Dabih", "β1 Cap", 305.25283, -14.78139, 45.42, 1.18, 3.08},
+		{"Sadr", "γ Cyg", 305.55708, 40.25667, 2.43, -0.93, 2.23},
+		{"Peacock", "α Pav", 306.41192, -56.73508, 6.90, -86.02, 1.94},
+		{"Deneb", "α Cyg", 310.35796, 45.28033, 2.01, 1.85, 1.25},
+		{"Alderamin", "α Cep", 319.64488, 62.58558, 150.55, 49.09, 2.45},
+		{"Sadalsuud", "β Aqr", 322.88971, -5.57117, 18.77, -8.21, 2.87},
+		{"Nashira", "γ Cap", 325.02275, -16.66231, 187.64, -22.66, 3.67},
+		{"Enif", "ε Peg", 326.04650, 9.87500, 26.92, 0.44, 2.39},
+		{"Deneb Algedi", "δ Cap", 326.76017, -16.12728, 263.26, -296.23, 2.87},
+		{"Sadalmelik", "α Aqr", 331.44600, -0.31986, 17.90, -9.93, 2.94},
+		{"Alnair", "α Gru", 332.05825, -46.96097, 126.69, -147.47, 1.74},
+		{"Sadachbia", "γ Aqr", 335.41408, -1.38733, 129.35, 9.33, 3.84},
+		{"Skat", "δ Aqr", 343.66254, -15.82081, -42.47, -26.19, 3.27},
+		{"Fomalhaut", "α PsA", 344.41271, -29.62225, 328.95, -164.67, 1.16},
+		{"Scheat", "β Peg", 345.94358, 28.08278, 187.65, 136.93, 2.42},
+		{"Markab", "α Peg", 346.19021, 15.20528, 60.40, -41.30, 2.49},
+	}
+	// The Messier and Caldwell catalogs. Positions are J2000.
+	deepSky = []DeepSkyObject{
+		{"M1", "NGC 1952", "Crab Nebula", "supernova remnant", 83.625, 22.017, 8.4, 6},
+		{"M2", "NGC 7089", "", "globular cluster", 323.375, -0.817, 6.5, 16},
+		{"M3", "NGC 5272", "", "globular cluster", 205.550, 28.383, 6.2, 18},
+		{"M4", "NGC 6121", "", "globular cluster", 245.900, -26.533, 5.6, 36},
+		{"M5", "NGC 5904", "", "globular cluster", 229.650, 2.083, 5.6, 23},
+		{"M6", "NGC 6405", "Butterfly Cluster", "open cluster", 265.025, -32.217, 4.2, 25},
+		{"M7", "NGC 6475", "Ptolemy Cluster", "open cluster", 268.475, -34.817, 3.3, 80},
+		{"M8", "NGC 6523", "Lagoon Nebula", "nebula", 270.950, -24.383, 6.0, 90},
+		{"M9", "NGC 6333", "", "globular cluster", 259.800, -18.517, 7.7, 12},
+		{"M10", "NGC 6254", "", "globular cluster", 254.275, -4.100, 6.6, 20},
+		{"M11", "NGC 6705", "Wild Duck Cluster", "open cluster", 282.775, -6.267, 5.8, 14},
+		{"M12", "NGC 6218", "", "globular cluster", 251.800, -1.950, 6.7, 16},
+		{"M13", "NGC 6205", "Hercules Cluster", "globular cluster", 250.425, 36.467, 5.8, 20},
+		{"M14", "NGC 6402", "", "globular cluster", 264.400, -3.250, 7.6, 11},
+		{"M15", "NGC 7078", "", "globular cluster", 322.500, 12.167, 6.2, 18},
+		{"M16", "NGC 6611", "Eagle Nebula", "nebula", 274.700, -13.783, 6.0, 35},
+		{"M17", "NGC 6618", "Omega Nebula", "nebula", 275.200, -16.183, 6.0, 11},
+		{"M18", "NGC 6613", "", "open cluster", 274.975, -17.133, 7.5, 9},
+		{"M19", "NGC 6273", "", "globular cluster", 255.650, -26.267, 6.8, 17},
+		{"M20", "NGC 6514", "Trifid Nebula", "nebula", 270.650, -23.033, 6.3, 28},
+		{"M21", "NGC 6531", "", "open cluster", 271.150, -22.500, 6.5, 13},
+		{"M22", "NGC 6656", "", "globular cluster", 279.100, -23.900, 5.1, 32},
+		{"M23", "NGC 6494", "", "open cluster", 269.200, -19.017, 6.9, 27},
+		{"M24", "IC 4715", "Sagittarius Star Cloud", "star cloud", 274.225, -18.483, 4.6, 90},
+		{"M25", "IC 4725", "", "open cluster", 277.900, -19.250, 4.6, 32},
+		{"M26", "NGC 6694", "", "open cluster", 281.300, -9.400, 8.0, 15},
+		{"M27", "NGC 6853", "Dumbbell Nebula", "planetary nebula", 299.900, 22.717, 7.5, 8},
+		{"M28", "NGC 6626", "", "globular cluster", 276.125, -24.867, 6.8, 11},
+		{"M29", "NGC 6913", "", "open cluster", 305.975, 38.533, 7.1, 7},
+		{"M30", "NGC 7099", "", "globular cluster", 325.100, -23.183, 7.2, 12},
+		{"M31", "NGC 224", "Andromeda Galaxy", "galaxy", 10.675, 41.267, 3.4, 178},
+		{"M32", "NGC 221", "", "galaxy", 10.675, 40.867, 8.1, 8},
+		{"M33", "NGC 598", "Triangulum Galaxy", "galaxy", 23.475, 30.650, 5.7, 73},
+		{"M34", "NGC 1039", "", "open cluster", 40.500, 42.783, 5.5, 35},
+		{"M35", "NGC 2168", "", "open cluster", 92.225, 24.333, 5.3, 28},
+		{"M36", "NGC 1960", "", "open cluster", 84.025, 34.133, 6.3, 12},
+		{"M37", "NGC 2099", "", "open cluster", 88.100, 32.550, 6.2, 24},
+		{"M38", "NGC 1912", "", "open cluster", 82.175, 35.833, 7.4, 21},
+		{"M39", "NGC 7092", "", "open cluster", 323.050, 48.433, 4.6, 32},
+		{"M40", "", "Winnecke 4", "double star", 185.600, 58.083, 8.4, 1},
+		{"M41", "NGC 2287", "", "open cluster", 101.500, -20.733, 4.5, 38},
+		{"M42", "NGC 1976", "Orion Nebula", "nebula", 83.850, -5.450, 4.0, 85},
+		{"M43", "NGC 1982", "De Mairan’s Nebula", "nebula", 83.900, -5.267, 9.0, 20},
+		{"M44", "NGC 2632", "Beehive Cluster", "open cluster", 130.025, 19.983, 3.7, 95},
+		{"M45", "", "Pleiades", "open cluster", 56.750, 24.117, 1.6, 110},
+		{"M46", "NGC 2437", "", "open cluster", 115.450, -14.817, 6.1, 27},
+		{"M47", "NGC 2422", "", "open cluster", 114.150, -14.500, 4.2, 30},
+		{"M48", "NGC 2548", "", "open cluster", 123.450, -5.800, 5.5, 54},
+		{"M49", "NGC 4472", "", "galaxy", 187.450, 8.000, 8.4, 10},
+		{"M50", "NGC 2323", "", "open cluster", 105.800, -8.333, 5.9, 16},
+		{"M51", "NGC 5194", "Whirlpool Galaxy", "galaxy", 202.475, 47.200, 8.4, 11},
+		{"M52", "NGC 7654", "", "open cluster", 351.050, 61.583, 7.3, 13},
+		{"M53", "NGC 5024", "", "globular cluster", 198.225, 18.167, 7.6, 13},
+		{"M54", "NGC 6715", "", "globular cluster", 283.775, -30.483, 7.6, 12},
+		{"M55", "NGC 6809", "", "globular cluster", 295.000, -30.967, 6.3, 19},
+		{"M56", "NGC 6779", "", "globular cluster", 289.150, 30.183, 8.3, 9},
+		{"M57", "NGC 6720", "Ring Nebula", "planetary nebula", 283.400, 33.033, 8.8, 1.4},
+		{"M58", "NGC 4579", "", "galaxy", 189.425, 11.817, 9.7, 6},
+		{"M59", "NGC 4621", "", "galaxy", 190.500, 11.650, 9.6, 5},
+		{"M60", "NGC 4649", "", "galaxy", 190.925, 11.550, 8.8, 7},
+		{"M61", "NGC 4303", "", "galaxy", 185.475, 4.467, 9.7, 6},
+		{"M62", "NGC 6266", "", "globular cluster", 255.300, -30.117, 6.5, 15},
+		{"M63", "NGC 5055", "Sunflower Galaxy", "galaxy", 198.950, 42.033, 8.6, 13},
+		{"M64", "NGC 4826", "Black Eye Galaxy", "galaxy", 194.175, 21.683, 8.5, 10},
+		{"M65", "NGC 3623", "", "galaxy", 169.725, 13.083, 9.3, 10},
+		{"M66", "NGC 3627", "", "galaxy", 170.050, 12.983, 8.9, 9},
+		{"M67", "NGC 2682", "", "open cluster", 132.825, 11.817, 6.1, 30},
+		{"M68", "NGC 4590", "", "globular cluster", 189.875, -26.750, 7.8, 12},
+		{"M69", "NGC 6637", "", "globular cluster", 277.850, -32.350, 7.6, 10},
+		{"M70", "NGC 6681", "", "globular cluster", 280.800, -32.300, 7.9, 8},
+		{"M71", "NGC 6838", "", "globular cluster", 298.450, 18.783, 8.2, 7},
+		{"M72", "NGC 6981", "", "globular cluster", 313.375, -12.533, 9.3, 7},
+		{"M73", "NGC 6994", "", "asterism", 314.725, -12.633, 9.0, 3},
+		{"M74", "NGC 628", "", "galaxy", 24.175, 15.783, 9.4, 10},
+		{"M75", "NGC 6864", "", "globular cluster", 301.525, -21.917, 8.5, 7},
+		{"M76", "NGC 650", "Little Dumbbell Nebula", "planetary nebula", 25.575, 51.567, 10.1, 3},
+		{"M77", "NGC 1068", "", "galaxy", 40.675, -0.017, 8.9, 7},
+		{"M78", "NGC 2068", "", "nebula", 86.700, 0.050, 8.3, 8},
+		{"M79", "NGC 1904", "", "globular cluster", 81.125, -24.550, 7.7, 10},
+		{"M80", "NGC 6093", "", "globular cluster", 244.250, -22.983, 7.3, 10},
+		{"M81", "NGC 3031", "Bode’s Galaxy", "galaxy", 148.900, 69.067, 6.9, 27},
+		{"M82", "NGC 3034", "Cigar Galaxy", "galaxy", 148.950, 69.683, 8.4, 11},
+		{"M83", "NGC 5236", "Southern Pinwheel Galaxy", "galaxy", 204.250, -29.867, 7.5, 13},
+		{"M84", "NGC 4374", "", "galaxy", 186.275, 12.883, 9.1, 7},
+		{"M85", "NGC 4382", "", "galaxy", 186.350, 18.183, 9.1, 7},
+		{"M86", "NGC 4406", "", "galaxy", 186.550, 12.950, 8.9, 9},
+		{"M87", "NGC 4486", "Virgo A", "galaxy", 187.700, 12.383, 8.6, 8},
+		{"M88", "NGC 4501", "", "galaxy", 188.000, 14.417, 9.6, 7},
+		{"M89", "NGC 4552", "", "galaxy", 188.925, 12.550, 9.8, 5},
+		{"M90", "NGC 4569", "", "galaxy", 189.200, 13.167, 9.5, 10},
+		{"M91", "NGC 4548", "", "galaxy", 188.850, 14.500, 10.2, 5},
+		{"M92", "NGC 6341", "", "globular cluster", 259.275, 43.133, 6.4, 14},
+		{"M93", "NGC 2447", "", "open cluster", 116.150, -23.867, 6.0, 22},
+		{"M94", "NGC 4736", "", "galaxy", 192.725, 41.117, 8.2, 11},
+		{"M95", "NGC 3351", "", "galaxy", 161.000, 11.700, 9.7, 7},
+		{"M96", "NGC 3368", "", "galaxy", 161.700, 11.817, 9.2, 8},
+		{"M97", "NGC 3587", "Owl Nebula", "planetary nebula", 168.700, 55.017, 9.9, 3},
+		{"M98", "NGC 4192", "", "galaxy", 183.450, 14.900, 10.1, 10},
+		{"M99", "NGC 4254", "", "galaxy", 184.700, 14.417, 9.9, 5},
+		{"M100", "NGC 4321", "", "galaxy", 185.725, 15.817, 9.3, 7},
+		{"M101", "NGC 5457", "Pinwheel Galaxy", "galaxy", 210.800, 54.350, 7.9, 29},
+		{"M102", "NGC 5866", "Spindle Galaxy", "galaxy", 226.625, 55.767, 9.9, 5},
+		{"M103", "NGC 581", "", "open cluster", 23.350, 60.650, 7.4, 6},
+		{"M104", "NGC 4594", "Sombrero Galaxy", "galaxy", 190.000, -11.617, 8.0, 9},
+		{"M105", "NGC 3379", "", "galaxy", 161.950, 12.583, 9.3, 5},
+		{"M106", "NGC 4258", "", "galaxy", 184.750, 47.300, 8.4, 19},
+		{"M107", "NGC 6171", "", "globular cluster", 248.125, -13.050, 7.9, 13},
+		{"M108", "NGC 3556", "", "galaxy", 167.875, 55.667, 10.0, 9},
+		{"M109", "NGC 3992", "", "galaxy", 179.400, 53.383, 9.8, 8},
+		{"M110", "NGC 205", "", "galaxy", 10.100, 41.683, 8.5, 22},
+		{"C1", "NGC 188", "", "open cluster", 11.100, 85.333, 8.1, 14},
+		{"C2", "NGC 40", "Bow-Tie Nebula", "planetary nebula", 3.250, 72.533, 11.4, 0.6},
+		{"C3", "NGC 4236", "", "galaxy", 184.175, 69.467, 9.7, 21},
+		{"C4", "NGC 7023", "Iris Nebula", "nebula", 315.400, 68.167, 6.8, 18},
+		{"C5", "IC 342", "", "galaxy", 56.700, 68.100, 9.1, 21},
+		{"C6", "NGC 6543", "Cat’s Eye Nebula", "planetary nebula", 269.650, 66.633, 8.1, 0.3},
+		{"C7", "NGC 2403", "", "galaxy", 114.225, 65.600, 8.4, 18},
+		{"C8", "NGC 559", "", "open cluster", 22.375, 63.300, 9.5, 4},
+		{"C9", "Sh2-155", "Cave Nebula", "nebula", 344.200, 62.617, 7.7, 50},
+		{"C10", "NGC 663", "", "open cluster", 26.500, 61.250, 7.1, 16},
+		{"C11", "NGC 7635", "Bubble Nebula", "nebula", 350.175, 61.200, 10.0, 15},
+		{"C12", "NGC 6946", "Fireworks Galaxy", "galaxy", 308.700, 60.150, 8.9, 11},
+		{"C13", "NGC 457", "Owl Cluster", "open cluster", 19.775, 58.333, 6.4, 13},
+		{"C14", "NGC 869", "Double Cluster", "open cluster", 35.000, 57.133, 4.3, 60},
+		{"C15", "NGC 6826", "Blinking Planetary", "planetary nebula", 296.200, 50.517, 9.8, 0.5},
+		{"C16", "NGC 7243", "", "open cluster", 333.825, 49.883, 6.4, 21},
+		{"C17", "NGC 147", "", "galaxy", 8.300, 48.500, 9.3, 13},
+		{"C18", "NGC 185", "", "galaxy", 9.750, 48.333, 9.2, 12},
+		{"C19", "IC 5146", "Cocoon Nebula", "nebula", 328.375, 47.267, 10.0, 12},
+		{"C20", "NGC 7000", "North America Nebula", "nebula", 314.700, 44.333, 4.0, 120},
+		{"C21", "NGC 4449", "", "galaxy", 187.050, 44.100, 9.4, 5},
+		{"C22", "NGC 7662", "Blue Snowball", "planetary nebula", 351.475, 42.550, 8.3, 0.3},
+		{"C23", "NGC 891", "", "galaxy", 35.650, 42.350, 9.9, 14},
+		{"C24", "NGC 1275", "Perseus A", "galaxy", 49.950, 41.517, 11.6, 2.6},
+		{"C25", "NGC 2419", "", "globular cluster", 114.525, 38.883, 10.4, 4},
+		{"C26", "NGC 4244", "", "galaxy", 184.375, 37.817, 10.2, 16},
+		{"C27", "NGC 6888", "Crescent Nebula", "nebula", 303.000, 38.350, 7.4, 18},
+		{"C28", "NGC 752", "", "open cluster", 29.450, 37.683, 5.7, 50},
+		{"C29", "NGC 5005", "", "galaxy", 197.725, 37.050, 9.8, 5},
+		{"C30", "NGC 7331", "", "galaxy", 339.275, 34.417, 9.5, 11},
+		{"C31", "IC 405", "Flaming Star Nebula", "nebula", 79.050, 34.267, 6.0, 30},
+		{"C32", "NGC 4631", "Whale Galaxy", "galaxy", 190.525, 32.533, 9.3, 15},
+		{"C33", "NGC 6992", "Eastern Veil Nebula", "supernova remnant", 314.100, 31.717, 7.0, 60},
+		{"C34", "NGC 6960", "Western Veil Nebula", "supernova remnant", 311.425, 30.717, 7.0, 70},
+		{"C35", "NGC 4889", "", "galaxy", 195.025, 27.983, 11.4, 3},
+		{"C36", "NGC 4559", "", "galaxy", 189.000, 27.967, 9.9, 13},
+		{"C37", "NGC 6885", "", "open cluster", 303.000, 26.483, 5.7, 20},
+		{"C38", "NGC 4565", "Needle Galaxy", "galaxy", 189.075, 25.983, 9.6, 16},
+		{"C39", "NGC 2392", "Eskimo Nebula", "planetary nebula", 112.300, 20.917, 9.1, 0.7},
+		{"C40", "NGC 3626", "", "galaxy", 170.025, 18.350, 10.9, 3},
+		{"C41", "", "Hyades", "open cluster", 66.750, 16.000, 0.5, 330},
+		{"C42", "NGC 7006", "", "globular cluster", 315.375, 16.183, 10.6, 3},
+		{"C43", "NGC 7814", "", "galaxy", 0.825, 16.150, 10.5, 6},
+		{"C44", "NGC 7479", "", "galaxy", 346.225, 12.317, 10.8, 4},
+		{"C45", "NGC 5248", "", "galaxy", 204.375, 8.883, 10.2, 6},
+		{"C46", "NGC 2261", "Hubble’s Variable Nebula", "nebula", 99.800, 8.733, 10.0, 2},
+		{"C47", "NGC 6934", "", "globular cluster", 308.550, 7.400, 8.9, 6},
+		{"C48", "NGC 2775", "", "galaxy", 137.575, 7.033, 10.1, 4},
+		{"C49", "NGC 2237", "Rosette Nebula", "nebula", 98.075, 5.050, 9.0, 80},
+		{"C50", "NGC 2244", "", "open cluster", 98.100, 4.867, 4.8, 24},
+		{"C51", "IC 1613", "", "galaxy", 16.200, 2.117, 9.2, 16},
+		{"C52", "NGC 4697", "", "galaxy", 192.150, -5.800, 9.3, 6},
+		{"C53", "NGC 3115", "Spindle Galaxy", "galaxy", 151.300, -7.717, 8.9, 8},
+		{"C54", "NGC 2506", "", "open cluster", 120.050, -10.783, 7.6, 7},
+		{"C55", "NGC 7009", "Saturn Nebula", "planetary nebula", 316.050, -11.367, 8.0, 0.6},
+		{"C56", "NGC 246", "Skull Nebula", "planetary nebula", 11.750, -11.867, 10.9, 4},
+		{"C57", "NGC 6822", "Barnard’s Galaxy", "galaxy", 296.225, -14.800, 9.3, 15},
+		{"C58", "NGC 2360", "", "open cluster", 109.425, -15.633, 7.2, 13},
+		{"C59", "NGC 3242", "Ghost of Jupiter", "planetary nebula", 156.200, -18.633, 8.6, 0.6},
+		{"C60", "NGC 4038", "Antennae", "galaxy", 180.475, -18.867, 10.5, 3},
+		{"C61", "NGC 4039", "Antennae", "galaxy", 180.475, -18.883, 10.3, 3},
+		{"C62", "NGC 247", "", "galaxy", 11.775, -20.767, 9.1, 20},
+		{"C63", "NGC 7293", "Helix Nebula", "planetary nebula", 337.400, -20.833, 7.3, 16},
+		{"C64", "NGC 2362", "", "open cluster", 109.700, -24.950, 4.1, 8},
+		{"C65", "NGC 253", "Sculptor Galaxy", "galaxy", 11.900, -25.283, 7.1, 27},
+		{"C66", "NGC 5694", "", "globular cluster", 219.900, -26.533, 10.2, 4},
+		{"C67", "NGC 1097", "", "galaxy", 41.575, -30.283, 9.2, 9},
+		{"C68", "NGC 6729", "R CrA Nebula", "nebula", 285.475, -36.950, 9.7, 1},
+		{"C69", "NGC 6302", "Bug Nebula", "planetary nebula", 258.425, -37.100, 9.6, 0.8},
+		{"C70", "NGC 300", "", "galaxy", 13.725, -37.683, 8.1, 22},
+		{"C71", "NGC 2477", "", "open cluster", 118.075, -38.533, 5.8, 27},
+		{"C72", "NGC 55", "", "galaxy", 3.725, -39.183, 7.9, 32},
+		{"C73", "NGC 1851", "", "globular cluster", 78.525, -40.050, 7.3, 11},
+		{"C74", "NGC 3132", "Eight-Burst Nebula", "planetary nebula", 151.925, -40.433, 9.4, 0.8},
+		{"C75", "NGC 6124", "", "open cluster", 246.400, -40.667, 5.8, 29},
+		{"C76", "NGC 6231", "", "open cluster", 253.500, -41.800, 2.6, 15},
+		{"C77", "NGC 5128", "Centaurus A", "galaxy", 201.375, -43.017, 6.8, 18},
+		{"C78", "NGC 6541", "", "globular cluster", 272.000, -43.700, 6.6, 15},
+		{"C79", "NGC 3201", "", "globular cluster", 154.400, -46.417, 6.7, 18},
+		{"C80", "NGC 5139", "Omega Centauri", "globular cluster", 201.700, -47.483, 3.7, 36},
+		{"C81", "NGC 6352", "", "globular cluster", 261.375, -48.417, 8.1, 7},
+		{"C82", "NGC 6193", "", "open cluster", 250.325, -48.767, 5.2, 15},
+		{"C83", "NGC 4945", "", "galaxy", 196.350, -49.467, 8.4, 20},
+		{"C84", "NGC 5286", "", "globular cluster", 206.600, -51.367, 7.6, 9},
+		{"C85", "IC 2391", "Omicron Velorum Cluster", "open cluster", 130.050, -53.067, 2.5, 50},
+		{"C86", "NGC 6397", "", "globular cluster", 265.175, -53.667, 5.7, 26},
+		{"C87", "NGC 1261", "", "globular cluster", 48.075, -55.217, 8.4, 7},
+		{"C88", "NGC 5823", "", "open cluster", 226.425, -55.600, 7.9, 10},
+		{"C89", "NGC 6087", "", "open cluster", 244.725, -57.900, 5.4, 12},
+		{"C90", "NGC 2867", "", "planetary nebula", 140.350, -58.317, 9.7, 0.2},
+		{"C91", "NGC 3532", "Wishing Well Cluster", "open cluster", 166.350, -58.733, 3.0, 55},
+		{"C92", "NGC 3372", "Eta Carinae Nebula", "nebula", 161.275, -59.867, 3.0, 120},
+		{"C93", "NGC 6752", "", "globular cluster", 287.725, -59.983, 5.4, 20},
+		{"C94", "NGC 4755", "Jewel Box", "open cluster", 193.400, -60.367, 4.2, 10},
+		{"C95", "NGC 6025", "", "open cluster", 240.925, -60.500, 5.1, 12},
+		{"C96", "NGC 2516", "", "open cluster", 119.575, -60.867, 3.8, 30},
+		{"C97", "NGC 3766", "Pearl Cluster", "open cluster", 174.025, -61.617, 5.3, 12},
+		{"C98", "NGC 4609", "", "open cluster", 190.575, -62.967, 6.9, 5},
+		{"C99", "", "Coalsack", "dark nebula", 193.250, -62.500, 0, 400},
+		{"C100", "IC 2944", "Lambda Centauri Nebula", "nebula", 174.150, -63.033, 4.5, 15},
+		{"C101", "NGC 6744", "", "galaxy", 287.450, -63.850, 9.0, 20},
+		{"C102", "IC 2602", "Southern Pleiades", "open cluster", 160.800, -64.400, 1.9, 50},
+		{"C103", "NGC 2070", "Tarantula Nebula", "nebula", 84.675, -69.100, 8.0, 40},
+		{"C104", "NGC 362", "", "globular cluster", 15.800, -70.850, 6.6, 13},
+		{"C105", "NGC 4833", "", "globular cluster", 194.900, -70.883, 7.3, 14},
+		{"C106", "NGC 104", "47 Tucanae", "globular cluster", 6.025, -72.083, 4.0, 31},
+		{"C107", "NGC 6101", "", "globular cluster", 246.450, -72.200, 9.3, 11},
+		{"C108", "NGC 4372", "", "globular cluster", 186.450, -72.667, 7.8, 19},
+		{"C109", "NGC 3195", "", "planetary nebula", 152.375, -80.867, 11.6, 0.6},
+	}
+)
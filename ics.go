@@ -0,0 +1,129 @@
+// Copyright 2024 Matthew P. Dargan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/matthewdargan/astro/ephem"
+)
+
+const icsTime = "20060102T150405Z"
+
+// endKinds maps the kind of an event that begins a span to the kind of the
+// event that ends it.
+var endKinds = map[ephem.Kind]ephem.Kind{
+	ephem.PartialEclipseStart: ephem.PartialEclipseEnd,
+	ephem.TotalEclipseStart:   ephem.TotalEclipseEnd,
+	ephem.OccultationStart:    ephem.OccultationEnd,
+	ephem.SolarTransitStart:   ephem.SolarTransitEnd,
+	ephem.GoldenHourStart:     ephem.GoldenHourEnd,
+	ephem.BlueHourStart:       ephem.BlueHourEnd,
+}
+
+type calendar struct {
+	w    *bufio.Writer
+	now  time.Time
+	uids map[string]bool
+}
+
+func newCalendar(w io.Writer) *calendar {
+	c := &calendar{w: bufio.NewWriter(w), now: time.Now().UTC(), uids: make(map[string]bool)}
+	c.line("BEGIN:VCALENDAR")
+	c.line("VERSION:2.0")
+	c.line("PRODID:-//matthewdargan//astro//EN")
+	c.line("CALSCALE:GREGORIAN")
+	return c
+}
+
+// add writes the events found by one search. An event that begins a span,
+// such as an eclipse, is joined with the event that ends it.
+func (c *calendar) add(events []ephem.Event) {
+	used := make([]bool, len(events))
+	for i, e := range events {
+		if used[i] {
+			continue
+		}
+		var end *ephem.Event
+		if k, ok := endKinds[e.Kind]; ok {
+			j := slices.IndexFunc(events, func(f ephem.Event) bool {
+				return f.Kind == k && slices.Equal(f.Bodies, e.Bodies) && !f.Time.Before(e.Time)
+			})
+			if j >= 0 && !used[j] {
+				used[j] = true
+				end = &events[j]
+			}
+		}
+		c.event(e, end)
+	}
+}
+
+func (c *calendar) event(e ephem.Event, end *ephem.Event) {
+	summary := e.Text
+	if end != nil {
+		summary = strings.TrimSuffix(summary, " begins")
+	}
+	key := fmt.Sprintf("%s|%s|%s", e.Kind, strings.Join(e.Bodies, ","), summary)
+	if e.Flag&ephem.Timed > 0 {
+		key += "|" + e.Time.UTC().Format(icsTime)
+	} else {
+		key += "|" + e.Time.UTC().Format(time.DateOnly)
+	}
+	uid := fmt.Sprintf("%x@astro", sha1.Sum([]byte(key)))
+	if c.uids[uid] {
+		return
+	}
+	c.uids[uid] = true
+	c.line("BEGIN:VEVENT")
+	c.line("UID:" + uid)
+	c.line("DTSTAMP:" + c.now.Format(icsTime))
+	if e.Flag&ephem.Timed > 0 {
+		c.line("DTSTART:" + e.Time.UTC().Format(icsTime))
+		if end != nil {
+			c.line("DTEND:" + end.Time.UTC().Format(icsTime))
+		}
+	} else {
+		c.line("DTSTART;VALUE=DATE:" + e.Time.UTC().Format("20060102"))
+	}
+	c.line("SUMMARY:" + icsEscape(summary))
+	c.line("CATEGORIES:" + icsEscape(string(e.Kind)))
+	if e.Flag&ephem.Signif > 0 {
+		c.line("PRIORITY:1")
+	} else {
+		c.line("PRIORITY:5")
+	}
+	c.line("TRANSP:TRANSPARENT")
+	c.line("END:VEVENT")
+}
+
+func (c *calendar) close() error {
+	c.line("END:VCALENDAR")
+	return c.w.Flush()
+}
+
+// line writes a content line, folding it at 75 octets as RFC 5545 requires.
+func (c *calendar) line(s string) {
+	limit := 75
+	for len(s) > limit {
+		n := limit
+		for n > 0 && s[n]&0xc0 == 0x80 { // Don't split a UTF-8 sequence.
+			n--
+		}
+		c.w.WriteString(s[:n] + "\r\n ")
+		s = s[n:]
+		limit = 74 // Leave room for the leading space.
+	}
+	c.w.WriteString(s + "\r\n")
+}
+
+func icsEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
@@ -0,0 +1,282 @@
+// Copyright 2024 Matthew P. Dargan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"math"
+	"os"
+	"time"
+
+	"github.com/matthewdargan/astro/ephem"
+)
+
+type julianRecord struct {
+	Julian float64 `json:"julian"`
+}
+
+type positionRecord struct {
+	Time   string  `json:"time"`
+	Body   string  `json:"body"`
+	RA     float64 `json:"ra"`
+	RADeg  float64 `json:"ra_deg"`
+	Dec    float64 `json:"dec"`
+	DecDeg float64 `json:"dec_deg"`
+	Az     float64 `json:"az"`
+	El     float64 `json:"el"`
+	Semi   float64 `json:"semi"`
+
+	// Omitted for the shadow, which has none.
+	Mag   *float64 `json:"mag,omitempty"`
+	Phase *float64 `json:"phase,omitempty"`
+	Illum *float64 `json:"illum,omitempty"`
+	Elong *float64 `json:"elong,omitempty"`
+	Limb  *float64 `json:"limb,omitempty"`
+}
+
+type eventRecord struct {
+	Type   string   `json:"type"`
+	Bodies []string `json:"bodies"`
+	Time   string   `json:"time,omitempty"`
+	Text   string   `json:"text"`
+	Signif bool     `json:"signif"`
+	Dark   bool     `json:"dark"`
+	Light  bool     `json:"light"`
+}
+
+type distRecord struct {
+	Time   string   `json:"time"`
+	Bodies []string `json:"bodies"`
+	Dist   float64  `json:"dist"`
+}
+
+type stationRecord struct {
+	Type          string  `json:"type"`
+	Body          string  `json:"body"`
+	Time          string  `json:"time"`
+	Lon           float64 `json:"lon"`
+	Constellation string  `json:"constellation"`
+}
+
+type jovianRecord struct {
+	Type   string  `json:"type"`
+	Time   string  `json:"time"`
+	Body   string  `json:"body"`
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Behind bool    `json:"behind"`
+}
+
+type solarRecord struct {
+	Type     string  `json:"type"`
+	Time     string  `json:"time"`
+	P        float64 `json:"p"`
+	B0       float64 `json:"b0"`
+	L0       float64 `json:"l0"`
+	Rotation int     `json:"rotation"`
+}
+
+type lunarRecord struct {
+	Type        string  `json:"type"`
+	Time        string  `json:"time"`
+	L           float64 `json:"l"`
+	B           float64 `json:"b"`
+	P           float64 `json:"p"`
+	Colongitude float64 `json:"colongitude"`
+	BrightLimb  float64 `json:"bright_limb"`
+	Morning     float64 `json:"morning"`
+	Evening     float64 `json:"evening"`
+}
+
+type phaseRecord struct {
+	Phase int    `json:"phase"`
+	Text  string `json:"text"`
+	Time  string `json:"time"`
+}
+
+type meteorRecord struct {
+	Time      string  `json:"time"`
+	Shower    string  `json:"shower"`
+	Name      string  `json:"name"`
+	ZHR       float64 `json:"zhr"`
+	RadiantEl float64 `json:"radiant_el"`
+	MoonEl    float64 `json:"moon_el"`
+	MoonIllum float64 `json:"moon_illum"`
+	Rate      float64 `json:"rate"`
+}
+
+type saturnRecord struct {
+	Type        string  `json:"type"`
+	Time        string  `json:"time"`
+	B           float64 `json:"b"`
+	BSun        float64 `json:"b_sun"`
+	P           float64 `json:"p"`
+	Crossing    string  `json:"crossing,omitempty"`
+	TitanX      float64 `json:"titan_x"`
+	TitanY      float64 `json:"titan_y"`
+	TitanBehind bool    `json:"titan_behind"`
+}
+
+type passPointRecord struct {
+	Time   string  `json:"time"`
+	Az     float64 `json:"az"`
+	El     float64 `json:"el"`
+	Sunlit bool    `json:"sunlit"`
+}
+
+type passRecord struct {
+	Satellite   string          `json:"satellite"`
+	Number      int             `json:"number"`
+	Rise        passPointRecord `json:"rise"`
+	Culmination passPointRecord `json:"culmination"`
+	Set         passPointRecord `json:"set"`
+	Visible     bool            `json:"visible"`
+}
+
+type windowRecord struct {
+	Object  string  `json:"object"`
+	NGC     string  `json:"ngc,omitempty"`
+	Name    string  `json:"name,omitempty"`
+	Type    string  `json:"type"`
+	Mag     float64 `json:"mag"`
+	Size    float64 `json:"size"`
+	Start   string  `json:"start"`
+	End     string  `json:"end"`
+	Transit string  `json:"transit,omitempty"`
+	MaxEl   float64 `json:"max_el"`
+}
+
+var enc = json.NewEncoder(os.Stdout)
+
+func writeJSON(v any) {
+	if err := enc.Encode(v); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func newPositionRecord(t time.Time, b ephem.Body, c ephem.Coord) positionRecord {
+	ra := math.Mod(c.RA+2*math.Pi, 2*math.Pi)
+	r := positionRecord{
+		Time:   clock(t).Format(time.RFC3339),
+		Body:   b.Name,
+		RA:     ra,
+		RADeg:  ra / radian,
+		Dec:    c.Decl,
+		DecDeg: c.Decl / radian,
+		Az:     c.Az,
+		El:     c.El,
+		Semi:   c.Semi,
+	}
+	if b.Name != "shadow" {
+		r.Mag, r.Phase, r.Illum, r.Elong, r.Limb = &c.Mag, &c.Phase, &c.Illum, &c.Elong, &c.Limb
+	}
+	return r
+}
+
+func newEventRecord(e ephem.Event) eventRecord {
+	r := eventRecord{
+		Type:   string(e.Kind),
+		Bodies: e.Bodies,
+		Text:   e.Text,
+		Signif: e.Flag&ephem.Signif > 0,
+		Dark:   e.Flag&ephem.Dark > 0,
+		Light:  e.Flag&ephem.Light > 0,
+	}
+	if r.Bodies == nil {
+		r.Bodies = []string{}
+	}
+	if e.Flag&ephem.Timed > 0 {
+		r.Time = clock(e.Time).Format(time.RFC3339)
+	}
+	return r
+}
+
+func newStationRecord(k ephem.Kind, b ephem.Body, s ephem.Station) stationRecord {
+	return stationRecord{
+		Type:          string(k),
+		Body:          b.Name,
+		Time:          clock(s.Time).Format(time.RFC3339),
+		Lon:           s.Lon,
+		Constellation: s.Constellation,
+	}
+}
+
+func newLunarRecord(t time.Time, d ephem.LunarDisk) lunarRecord {
+	morning, evening := d.Terminator()
+	return lunarRecord{
+		Type:        "lunar-disk",
+		Time:        clock(t).Format(time.RFC3339),
+		L:           d.L,
+		B:           d.B,
+		P:           d.P,
+		Colongitude: d.Colongitude,
+		BrightLimb:  d.BrightLimb,
+		Morning:     morning,
+		Evening:     evening,
+	}
+}
+
+func newMeteorRecord(n ephem.MeteorNight, h ephem.MeteorHour) meteorRecord {
+	return meteorRecord{
+		Time:      clock(h.Time).Format(time.RFC3339),
+		Shower:    n.Shower.Code,
+		Name:      n.Shower.Name,
+		ZHR:       n.ZHR,
+		RadiantEl: h.RadiantEl,
+		MoonEl:    h.MoonEl,
+		MoonIllum: h.MoonIllum,
+		Rate:      h.Rate,
+	}
+}
+
+func newSaturnRecord(t time.Time, a ephem.SaturnAspect) saturnRecord {
+	r := saturnRecord{
+		Type:        "saturn-rings",
+		Time:        clock(t).Format(time.RFC3339),
+		B:           a.B,
+		BSun:        a.BSun,
+		P:           a.P,
+		TitanX:      a.TitanX,
+		TitanY:      a.TitanY,
+		TitanBehind: a.Behind,
+	}
+	if !a.Crossing.IsZero() {
+		r.Crossing = clock(a.Crossing).Format(time.RFC3339)
+	}
+	return r
+}
+
+func newPassRecord(s ephem.Satellite, p ephem.Pass) passRecord {
+	pt := func(p ephem.PassPoint) passPointRecord {
+		return passPointRecord{Time: clock(p.Time).Format(time.RFC3339), Az: p.Az, El: p.El, Sunlit: p.Sunlit}
+	}
+	return passRecord{
+		Satellite:   s.Name,
+		Number:      s.Number,
+		Rise:        pt(p.Rise),
+		Culmination: pt(p.Culmination),
+		Set:         pt(p.Set),
+		Visible:     p.Visible,
+	}
+}
+
+func newWindowRecord(w ephem.Window) windowRecord {
+	r := windowRecord{
+		Object: w.Object.Name,
+		NGC:    w.Object.NGC,
+		Name:   w.Object.Common,
+		Type:   w.Object.Type,
+		Mag:    w.Object.Mag,
+		Size:   w.Object.Size,
+		Start:  clock(w.Start).Format(time.RFC3339),
+		End:    clock(w.End).Format(time.RFC3339),
+		MaxEl:  w.El,
+	}
+	if !w.Transit.IsZero() {
+		r.Transit = clock(w.Transit).Format(time.RFC3339)
+	}
+	return r
+}